@@ -0,0 +1,99 @@
+package every
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+)
+
+// AdminHandler serves admin endpoints for the Scheduler's tasks, taking
+// and returning JSON:
+//
+//	GET  /tasks                 the Snapshot of every task
+//	GET  /tasks/{id}            one task's TaskSnapshot
+//	POST /tasks/{id}/pause      Pause the task
+//	POST /tasks/{id}/resume     Resume it
+//	POST /tasks/{id}/run        RunNow
+//	POST /tasks/{id}/enable     Enable a disabled task
+//	PUT  /tasks/{id}/schedule   Reschedule to {"schedule": "5m"}
+//
+// The task endpoints reply with the task's snapshot after the change. The
+// handler has no authentication of its own, so mount it behind some, with
+// http.StripPrefix to put it under a path such as /admin/every.
+func (s *Scheduler) AdminHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /tasks", func(w http.ResponseWriter, r *http.Request) {
+		snapshots := s.Snapshot()
+		if snapshots == nil {
+			snapshots = []TaskSnapshot{}
+		}
+		writeJSON(w, snapshots)
+	})
+	mux.HandleFunc("GET /tasks/{id}", s.admin(func(*Task, *http.Request) error { return nil }))
+	mux.HandleFunc("POST /tasks/{id}/pause", s.admin(func(t *Task, _ *http.Request) error {
+		t.Pause()
+		return nil
+	}))
+	mux.HandleFunc("POST /tasks/{id}/resume", s.admin(func(t *Task, _ *http.Request) error {
+		t.Resume()
+		return nil
+	}))
+	mux.HandleFunc("POST /tasks/{id}/run", s.admin(func(t *Task, _ *http.Request) error {
+		return t.RunNow()
+	}))
+	mux.HandleFunc("POST /tasks/{id}/enable", s.admin(func(t *Task, _ *http.Request) error {
+		return t.Enable()
+	}))
+	mux.HandleFunc("PUT /tasks/{id}/schedule", s.admin(func(t *Task, r *http.Request) error {
+		var body struct {
+			Schedule string `json:"schedule"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			return badRequest{err}
+		}
+		err := t.Reschedule(body.Schedule)
+		if err != nil && !errors.Is(err, ErrTaskStopped) {
+			return badRequest{err}
+		}
+		return err
+	}))
+	return mux
+}
+
+// badRequest marks an error in the request rather than the task.
+type badRequest struct{ error }
+
+// admin adapts an operation on the task named by the request's {id} to an
+// admin endpoint.
+func (s *Scheduler) admin(op func(*Task, *http.Request) error) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.ParseUint(r.PathValue("id"), 10, 64)
+		t, ok := s.Task(TaskID(id))
+		if err != nil || !ok {
+			http.Error(w, "no task with id "+r.PathValue("id"), http.StatusNotFound)
+			return
+		}
+		if err := op(t, r); err != nil {
+			http.Error(w, err.Error(), adminStatus(err))
+			return
+		}
+		writeJSON(w, t.Snapshot())
+	}
+}
+
+func adminStatus(err error) int {
+	var bad badRequest
+	switch {
+	case errors.As(err, &bad):
+		return http.StatusBadRequest
+	case errors.Is(err, ErrTaskStopped), errors.Is(err, ErrNotStarted):
+		return http.StatusConflict
+	}
+	return http.StatusInternalServerError
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,71 @@
+package every
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestAdminHandler(t *testing.T) {
+	s := NewScheduler()
+	task, _ := NewTask("1h", func() {}, WithName("report"))
+	id := s.Add(task)
+	h := http.StripPrefix("/admin", s.AdminHandler())
+
+	// The fields of a TaskSnapshot the test looks at.
+	type snapshot struct {
+		ID       TaskID `json:"id"`
+		Schedule string `json:"schedule"`
+		Paused   bool   `json:"paused"`
+	}
+	do := func(method, path, body string) (int, snapshot) {
+		t.Helper()
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(method, "/admin"+path, strings.NewReader(body)))
+		var snap snapshot
+		if w.Code == http.StatusOK {
+			if err := json.Unmarshal(w.Body.Bytes(), &snap); err != nil {
+				t.Fatalf("%s %s: %v", method, path, err)
+			}
+		}
+		return w.Code, snap
+	}
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "/admin/tasks", nil))
+	var list []snapshot
+	if err := json.Unmarshal(w.Body.Bytes(), &list); err != nil || len(list) != 1 || list[0].ID != id {
+		t.Fatalf("GET /tasks = %s, %v", w.Body, err)
+	}
+
+	if code, snap := do("POST", "/tasks/1/pause", ""); code != http.StatusOK || !snap.Paused {
+		t.Errorf("pause: %d, paused %v", code, snap.Paused)
+	}
+	if code, snap := do("POST", "/tasks/1/resume", ""); code != http.StatusOK || snap.Paused {
+		t.Errorf("resume: %d, paused %v", code, snap.Paused)
+	}
+
+	for _, tc := range []struct {
+		method, path, body string
+		code               int
+	}{
+		{"GET", "/tasks/2", "", http.StatusNotFound},
+		{"GET", "/tasks/report", "", http.StatusNotFound},
+		{"POST", "/tasks/1/run", "", http.StatusConflict},
+		{"PUT", "/tasks/1/schedule", `{"schedule": "5w"}`, http.StatusBadRequest},
+		{"PUT", "/tasks/1/schedule", `5m`, http.StatusBadRequest},
+		{"DELETE", "/tasks/1", "", http.StatusMethodNotAllowed},
+	} {
+		if code, _ := do(tc.method, tc.path, tc.body); code != tc.code {
+			t.Errorf("%s %s: %d, want %d", tc.method, tc.path, code, tc.code)
+		}
+	}
+
+	s.StartAll()
+	defer s.StopAll()
+	if code, snap := do("PUT", "/tasks/1/schedule", `{"schedule": "5m"}`); code != http.StatusOK || snap.Schedule != "5m" {
+		t.Errorf("schedule: %d, %q", code, snap.Schedule)
+	}
+}
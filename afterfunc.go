@@ -0,0 +1,142 @@
+package every
+
+import (
+	"math"
+	"time"
+)
+
+// WithAfterFunc runs the task on time.AfterFunc instead of a dedicated
+// goroutine, so an idle task costs only a runtime timer. It suits programs
+// with many mostly idle, long-interval tasks; each fire then starts a
+// fresh goroutine, which costs slightly more than the default loop. The
+// public API behaves the same either way.
+func WithAfterFunc() Option {
+	return func(t *Task) {
+		t.afterFunc = true
+	}
+}
+
+func (t *Task) startAfterFunc() {
+	// The warm-up may block, so it gets a short-lived goroutine; once it
+	// is done nothing runs until a timer fires.
+	t.wg.Add(1)
+	go func() {
+		defer t.wg.Done()
+
+		if !t.warmUp() {
+			t.setState(StateStopped, time.Time{})
+			t.exited()
+			return
+		}
+
+		t.loopMu.Lock()
+		defer t.loopMu.Unlock()
+
+		if t.stopping.Load() {
+			t.setState(StateStopped, time.Time{})
+			t.exited()
+			return
+		}
+		t.timer = time.AfterFunc(math.MaxInt64, t.afterFuncFire)
+		t.timer.Stop()
+		kick := time.AfterFunc(math.MaxInt64, t.afterFuncTrigger)
+		kick.Stop()
+		t.kick.Store(kick)
+		t.begin()
+		if t.kickPending.Load() {
+			kick.Reset(0)
+		}
+		if t.extTrigger != nil {
+			t.wg.Add(1)
+			go t.forwardTriggers()
+		}
+	}()
+}
+
+// forwardTriggers feeds WithTrigger's channel to the task, the one thing
+// in AfterFunc mode that needs a goroutine of its own.
+func (t *Task) forwardTriggers() {
+	defer t.wg.Done()
+
+	for {
+		select {
+		case <-t.stopChan:
+			return
+		case _, ok := <-t.extTrigger:
+			if !ok {
+				return
+			}
+			t.loopMu.Lock()
+			if !t.stopping.Load() {
+				t.external()
+			}
+			t.loopMu.Unlock()
+		}
+	}
+}
+
+func (t *Task) afterFuncFire() {
+	t.loopMu.Lock()
+	defer t.loopMu.Unlock()
+	if t.supervisor != nil {
+		defer t.recoverAfterFunc()
+	}
+
+	if !t.stopping.Load() {
+		t.fire(time.Now())
+	}
+}
+
+func (t *Task) afterFuncTrigger() {
+	t.loopMu.Lock()
+	defer t.loopMu.Unlock()
+	if t.supervisor != nil {
+		defer t.recoverAfterFunc()
+	}
+
+	t.kickPending.Store(false)
+	if !t.stopping.Load() {
+		t.triggered()
+	}
+}
+
+// kickAfterFunc schedules trigger handling without blocking the caller,
+// coalescing requests made while one is pending.
+func (t *Task) kickAfterFunc() {
+	if !t.kickPending.CompareAndSwap(false, true) {
+		return
+	}
+	if kick := t.kick.Load(); kick != nil {
+		kick.Reset(0)
+	}
+}
+
+func (t *Task) updateAfterFunc(s schedule) error {
+	t.loopMu.Lock()
+	defer t.loopMu.Unlock()
+
+	if t.stopping.Load() {
+		return ErrTaskStopped
+	}
+	if t.timer == nil {
+		t.mu.Lock()
+		t.schedule = s
+		t.mu.Unlock()
+		return nil
+	}
+	t.reschedule(s)
+	return nil
+}
+
+func (t *Task) stopAfterFunc() {
+	t.loopMu.Lock()
+	defer t.loopMu.Unlock()
+
+	if t.timer == nil {
+		return
+	}
+	t.timer.Stop()
+	t.kick.Load().Stop()
+	t.timer = nil
+	t.end()
+}
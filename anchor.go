@@ -0,0 +1,80 @@
+package every
+
+import (
+	"fmt"
+	"time"
+)
+
+// anchoredSchedule fires at anchor and every interval after it, so fires
+// keep a fixed phase to an external epoch however long runs take. Like
+// other calendar schedules it follows the wall clock.
+type anchoredSchedule struct {
+	every  time.Duration
+	anchor time.Time
+}
+
+func (s anchoredSchedule) next(t time.Time) time.Time {
+	if t.Before(s.anchor) {
+		return s.anchor
+	}
+	n := t.Sub(s.anchor)/s.every + 1
+	return s.anchor.Add(n * s.every)
+}
+
+func (s anchoredSchedule) String() string {
+	return formatDuration(s.every) + "@" + s.anchor.Format(time.RFC3339)
+}
+
+// dayCycleSchedule fires at a time of day every days calendar days,
+// counted from 1970-01-01 so the cycle carries over restarts. It steps in
+// local calendar days, so unlike a 72h interval "3d@06:00" stays at 06:00
+// across DST changes.
+type dayCycleSchedule struct {
+	days int
+	dailySchedule
+}
+
+func (s dayCycleSchedule) next(t time.Time) time.Time {
+	n := s.dailySchedule.next(t)
+	for dayNumber(n)%s.days != 0 {
+		n = time.Date(n.Year(), n.Month(), n.Day()+1, s.hour, s.minute, 0, 0, n.Location())
+	}
+	return n
+}
+
+func (s dayCycleSchedule) String() string {
+	return fmt.Sprintf("%dd@%02d:%02d", s.days, s.hour, s.minute)
+}
+
+// dayNumber numbers t's calendar date in its own location, counting days
+// from 1970-01-01.
+func dayNumber(t time.Time) int {
+	y, m, d := t.Date()
+	days := time.Date(y, m, d, 0, 0, 0, 0, time.UTC).Unix() / 86400
+	return int(days)
+}
+
+// parseAnchored parses "<interval>@<RFC 3339 time>", such as
+// "24h@2025-07-01T00:00:00Z", or "<days>d@<HH:MM>", such as "3d@06:00".
+func (p parser) parseAnchored(spec, every, anchor string) (schedule, error) {
+	d, err := p.interval(every)
+	if err != nil {
+		return nil, err
+	}
+	if len(anchor) == len("15:04") {
+		const day = 24 * time.Hour
+		if time.Duration(d)%day != 0 {
+			return nil, fmt.Errorf("%w: %s is not a whole number of days in %s", ErrInvalidValue, every, spec)
+		}
+		daily, err := parseClock(spec, anchor)
+		if err != nil {
+			return nil, err
+		}
+		return dayCycleSchedule{days: int(time.Duration(d) / day), dailySchedule: daily}, nil
+	}
+	at, err := time.Parse(time.RFC3339, anchor)
+	if err != nil {
+		return nil, fmt.Errorf("%w: time %s", ErrInvalidValue, spec)
+	}
+	return anchoredSchedule{every: time.Duration(d), anchor: at}, nil
+}
@@ -0,0 +1,141 @@
+package every
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+)
+
+// ApplyResult lists what Apply changed.
+type ApplyResult struct {
+	Added       []*Task
+	Rescheduled []*Task
+	Replaced    []*Task
+	Removed     []*Task
+}
+
+// Apply reconciles the Scheduler's tasks with defs, matching them by name:
+// tasks missing from the Scheduler are added, tasks whose definition only
+// differs in schedule are rescheduled, tasks differing in anything else
+// are stopped and replaced, and named tasks missing from defs are stopped
+// and removed. Tasks without a name are left alone. Applying the same defs
+// again changes nothing. Nothing changes unless every definition builds,
+// and new tasks are started if StartAll has been called.
+func (s *Scheduler) Apply(defs []TaskDefinition, r Registry) (ApplyResult, error) {
+	want := make(map[string]*Task, len(defs))
+	var built []*Task
+	defaults := s.defaultOptions()
+	for i, d := range defs {
+		if d.Name == "" {
+			return ApplyResult{}, fmt.Errorf("definition %d: missing name", i)
+		}
+		if _, ok := want[d.Name]; ok {
+			return ApplyResult{}, fmt.Errorf("definition %d: duplicate name %s", i, d.Name)
+		}
+		t, err := d.build(r, defaults, nil)
+		if err != nil {
+			for _, t := range built {
+				t.cancel()
+			}
+			return ApplyResult{}, fmt.Errorf("definition %d: %w", i, err)
+		}
+		want[d.Name] = t
+		built = append(built, t)
+	}
+
+	var (
+		res   ApplyResult
+		fresh []*Task
+		moves []schedule
+	)
+	s.mu.Lock()
+	running := s.running
+	have := make(map[string]*Task)
+	for _, t := range s.tasks {
+		if t.name != "" {
+			have[t.name] = t
+		}
+	}
+	for _, t := range have {
+		if _, ok := want[t.name]; !ok {
+			res.Removed = append(res.Removed, t)
+		}
+	}
+	for _, t := range built {
+		old, ok := have[t.name]
+		switch {
+		case !ok:
+			s.addLocked(t)
+			res.Added = append(res.Added, t)
+			fresh = append(fresh, t)
+		case old.taskFunc == nil && t.taskFunc != nil:
+			s.replaceLocked(old, t)
+			res.Replaced = append(res.Replaced, old)
+			fresh = append(fresh, t)
+		case sameDefinition(old, t, false):
+			t.cancel()
+		case sameDefinition(old, t, true):
+			t.cancel()
+			res.Rescheduled = append(res.Rescheduled, old)
+			moves = append(moves, t.schedule)
+		default:
+			s.replaceLocked(old, t)
+			res.Replaced = append(res.Replaced, old)
+			fresh = append(fresh, t)
+		}
+	}
+	for _, t := range res.Removed {
+		s.removeLocked(t)
+	}
+	s.mu.Unlock()
+
+	for _, t := range slices.Concat(res.Removed, res.Replaced) {
+		t.Stop()
+	}
+	for i, t := range res.Rescheduled {
+		t.setSchedule(moves[i])
+	}
+	if running {
+		for _, t := range fresh {
+			t.Start()
+		}
+	}
+	return res, nil
+}
+
+// sameDefinition reports whether a and b have the same definition, leaving
+// out their schedules if ignoreSchedule is set.
+func sameDefinition(a, b *Task, ignoreSchedule bool) bool {
+	da, db := a.Definition(), b.Definition()
+	if ignoreSchedule {
+		da.Schedule, db.Schedule = "", ""
+	}
+	if slices.Equal(da.Tags, db.Tags) {
+		da.Tags, db.Tags = nil, nil
+	}
+	return reflect.DeepEqual(da, db)
+}
+
+// setSchedule replaces the schedule of a task that may not have been
+// started yet, which Reschedule would wait on.
+func (t *Task) setSchedule(s schedule) {
+	if t.launched.Load() || t.afterFunc {
+		t.change(s)
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.schedule = s
+}
+
+// replaceLocked swaps t in for old, in old's Namespace if it has one.
+func (s *Scheduler) replaceLocked(old, t *Task) {
+	s.removeLocked(old)
+	t.ns = old.ns
+	s.addLocked(t)
+}
+
+func (s *Scheduler) removeLocked(t *Task) {
+	s.tasks = slices.DeleteFunc(s.tasks, func(other *Task) bool { return other == t })
+}
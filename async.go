@@ -0,0 +1,119 @@
+package every
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"time"
+)
+
+// WithAsyncRuns hands each fire to a goroutine of its own instead of
+// running it on the task's loop, so a run that outlasts the interval no
+// longer holds up the next fire and runs may overlap. The next fire is
+// scheduled from the fire time rather than from the end of the run. Stop
+// waits for every run in flight; see InFlight.
+//
+// A fire still waits for the task's pool, namespace and fences before it
+// is handed off, so they bound the overlap. Async runs time out, retry and
+// report like others, and recover panics as failures, but cannot be
+// preempted and do not support WithPanicLimit, WithCircuitBreaker,
+// WithJobStore, WithDelivery or WithQueue.
+func WithAsyncRuns() Option {
+	return func(t *Task) {
+		t.async = true
+	}
+}
+
+// InFlight returns how many runs of the task are in progress: at most one,
+// unless it was created WithAsyncRuns.
+func (t *Task) InFlight() int {
+	return int(t.inflight.Load())
+}
+
+// launch starts the run for t.due in a goroutine and schedules the next
+// fire after now.
+func (t *Task) launch(now time.Time) {
+	start := time.Now()
+	t.runs++
+	r := &run{task: t, info: RunInfo{ID: t.runs, Attempt: 1, Scheduled: t.due, Fired: start, Previous: t.lastScheduled, seq: runSeq.Add(1)}}
+	t.lastScheduled = t.due
+	t.sequence(&r.info)
+	t.lastRun.Store(start)
+
+	var dropped int
+	if t.events != nil {
+		t.due = t.events.ran(start, start)
+	} else {
+		t.due, dropped = t.following(now)
+	}
+	r.last = t.spent(t.due)
+	t.asyncRuns.Add(1)
+	go t.runAsync(context.WithValue(t.ctx, taskKey{}, r), r)
+	t.arm()
+	t.setState(StateWaiting, t.due)
+	t.traceNext()
+	t.skip(SkipDayOff, dropped)
+}
+
+// runAsync is execute and the bookkeeping after it for an async run,
+// touching nothing owned by the run loop.
+func (t *Task) runAsync(ctx context.Context, r *run) {
+	defer t.asyncRuns.Done()
+	if r.last {
+		defer t.retire()
+	}
+	defer t.inflight.Add(-1)
+	defer t.unlockFences()
+	defer t.ns.release()
+	if t.pool != nil {
+		defer t.pool.release(t)
+	}
+
+	start := r.info.Fired
+	err := t.callAsync(ctx)
+	for attempt := 1; err != nil && attempt <= t.retries; attempt++ {
+		delay := t.backoff.NextDelay(attempt)
+		t.log.Warn("task failed, retrying", "error", err, "attempt", attempt, "retry", delay, "run_id", r.info.RunID())
+		if !t.sleep(delay) {
+			break
+		}
+		r.info.Attempt = attempt + 1
+		r.info.Fired = time.Now()
+		err = t.callAsync(ctx)
+	}
+	t.subs.publish(ctx)
+	end := time.Now()
+
+	t.stats.ran(end.Sub(start), err)
+	if t.audit != nil || t.historySink != nil {
+		t.record(r.info.ID, r.info.Scheduled, start, end, err)
+	}
+	if err != nil {
+		t.log.Error("task failed", "error", err, "duration", end.Sub(start), "run_id", r.info.RunID())
+		if t.notifiers != nil {
+			t.notify(Event{Type: failureEvent(err), Time: start, Run: r.info.ID, Sequence: r.info.Sequence, Duration: end.Sub(start), Err: err})
+		}
+		return
+	}
+	if t.debug {
+		t.log.Debug("task ran", "duration", end.Sub(start), "run_id", r.info.RunID())
+	}
+	if t.heartbeat != nil {
+		t.heartbeat()
+	}
+	t.succeedOnce.Do(func() { close(t.succeeded) })
+}
+
+func (t *Task) callAsync(ctx context.Context) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+			t.log.Error("task panicked", "error", err, "stack", string(debug.Stack()))
+		}
+	}()
+	if t.profileLabels {
+		t.labelled(ctx, func(ctx context.Context) { err = t.invoke(ctx) })
+		return err
+	}
+	return t.invoke(ctx)
+}
@@ -0,0 +1,143 @@
+package every
+
+import (
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+)
+
+// AuditRecord is one line of an AuditLog.
+type AuditRecord struct {
+	Task      string        `json:"task"`
+	Run       uint64        `json:"run"`
+	Scheduled time.Time     `json:"scheduled"`
+	Start     time.Time     `json:"start"`
+	End       time.Time     `json:"end"`
+	Duration  time.Duration `json:"duration"`
+	Error     string        `json:"error,omitempty"`
+}
+
+// AuditLog appends a JSON line for every run of the tasks using it. Once
+// the file would grow past maxSize bytes, or is older than maxAge, it is
+// renamed with a UTC timestamp suffix and a new one started; zero
+// disables either limit. A file left by an earlier process is aged from
+// its modification time. Rotated files are never deleted.
+type AuditLog struct {
+	path    string
+	maxSize int64
+	maxAge  time.Duration
+
+	mu     sync.Mutex
+	f      *os.File
+	size   int64
+	opened time.Time
+}
+
+func NewAuditLog(path string, maxSize int64, maxAge time.Duration) (*AuditLog, error) {
+	a := &AuditLog{path: path, maxSize: maxSize, maxAge: maxAge}
+	if err := a.open(); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// WithAuditLog records every run of the task in a. Records are written
+// before the next fire is scheduled; write errors are logged.
+func WithAuditLog(a *AuditLog) Option {
+	return func(t *Task) {
+		t.audit = a
+	}
+}
+
+func (a *AuditLog) open() error {
+	f, err := os.OpenFile(a.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o644)
+	if err != nil {
+		return err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+	a.f, a.size, a.opened = f, info.Size(), info.ModTime()
+	return nil
+}
+
+func (a *AuditLog) Write(r AuditRecord) error {
+	line, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.f == nil {
+		return os.ErrClosed
+	}
+	// A failed rotation leaves the old file in place, so the record still
+	// goes somewhere and the next write tries again.
+	var rotateErr error
+	if a.due(int64(len(line))) {
+		rotateErr = a.rotate()
+	}
+	n, err := a.f.Write(line)
+	a.size += int64(n)
+	if err == nil {
+		err = rotateErr
+	}
+	return err
+}
+
+func (a *AuditLog) due(n int64) bool {
+	if a.size == 0 {
+		return false
+	}
+	return a.maxSize > 0 && a.size+n > a.maxSize || a.maxAge > 0 && time.Since(a.opened) > a.maxAge
+}
+
+// rotate moves the file aside and starts a new one. The old file stays open
+// until the new one is, and is moved back if the new one cannot be opened.
+func (a *AuditLog) rotate() error {
+	old := a.f
+	rotated := a.path + "." + time.Now().UTC().Format("20060102T150405.000")
+	if err := os.Rename(a.path, rotated); err != nil {
+		return err
+	}
+	if err := a.open(); err != nil {
+		os.Rename(rotated, a.path)
+		return err
+	}
+	return old.Close()
+}
+
+func (a *AuditLog) Close() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.f == nil {
+		return nil
+	}
+	err := a.f.Close()
+	a.f = nil
+	return err
+}
+
+func (t *Task) record(run uint64, scheduled, start, end time.Time, err error) {
+	r := AuditRecord{Task: t.name, Run: run, Scheduled: scheduled, Start: start, End: end, Duration: end.Sub(start)}
+	if err != nil {
+		r.Error = err.Error()
+	}
+	if t.audit != nil {
+		if err := t.audit.Write(r); err != nil {
+			t.log.Warn("audit log write failed", "error", err)
+		}
+	}
+	if t.historySink != nil {
+		if err := t.historySink.Write(r); err != nil {
+			t.log.Warn("history write failed", "error", err)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package every
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestAuditLogAgeFromModTime(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		age   time.Duration
+		files int
+	}{
+		{"fresh", time.Minute, 1},
+		{"stale", 2 * time.Hour, 2},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			path := filepath.Join(dir, "audit.log")
+			if err := os.WriteFile(path, []byte("{}\n"), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			old := time.Now().Add(-tc.age)
+			if err := os.Chtimes(path, old, old); err != nil {
+				t.Fatal(err)
+			}
+
+			a, err := NewAuditLog(path, 0, time.Hour)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer a.Close()
+			if err := a.Write(AuditRecord{Task: "sync", Run: 1}); err != nil {
+				t.Fatal(err)
+			}
+			if files, _ := os.ReadDir(dir); len(files) != tc.files {
+				t.Errorf("%d files after writing to a file %v old, want %d", len(files), tc.age, tc.files)
+			}
+		})
+	}
+}
+
+func TestAuditLogRotationKeepsWriting(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "audit.log")
+	a, err := NewAuditLog(path, 1, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer a.Close()
+
+	for run := range uint64(3) {
+		if err := a.Write(AuditRecord{Task: "sync", Run: run}); err != nil {
+			t.Fatal(err)
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if files, _ := os.ReadDir(dir); len(files) != 3 {
+		t.Errorf("%d files, want a current one and two rotated", len(files))
+	}
+}
@@ -0,0 +1,97 @@
+package every
+
+import (
+	"math"
+	"time"
+)
+
+// Backoff decides how long to wait before a retry. Attempts count from 1
+// for the wait after the first failure.
+type Backoff interface {
+	NextDelay(attempt int) time.Duration
+}
+
+// ConstantBackoff always waits Delay.
+type ConstantBackoff struct {
+	Delay time.Duration
+}
+
+func (b ConstantBackoff) NextDelay(int) time.Duration {
+	return b.Delay
+}
+
+// LinearBackoff waits Initial, then Step longer each attempt, up to Max if
+// it is set.
+type LinearBackoff struct {
+	Initial, Step, Max time.Duration
+}
+
+func (b LinearBackoff) NextDelay(attempt int) time.Duration {
+	return capDelay(float64(b.Initial)+float64(b.Step)*float64(attempt-1), b.Max)
+}
+
+// ExponentialBackoff waits Initial, multiplying the wait by Multiplier (2
+// if unset) each attempt, up to Max if it is set.
+type ExponentialBackoff struct {
+	Initial    time.Duration
+	Multiplier float64
+	Max        time.Duration
+}
+
+func (b ExponentialBackoff) NextDelay(attempt int) time.Duration {
+	m := b.Multiplier
+	if m <= 0 {
+		m = 2
+	}
+	return capDelay(float64(b.Initial)*math.Pow(m, float64(attempt-1)), b.Max)
+}
+
+// FibonacciBackoff waits Initial times the attempt'th Fibonacci number
+// (1, 1, 2, 3, 5, ...), up to Max if it is set.
+type FibonacciBackoff struct {
+	Initial, Max time.Duration
+}
+
+func (b FibonacciBackoff) NextDelay(attempt int) time.Duration {
+	a, c := 0.0, 1.0
+	for i := 0; i < attempt && a < math.MaxInt64; i++ {
+		a, c = c, a+c
+	}
+	return capDelay(float64(b.Initial)*a, b.Max)
+}
+
+func capDelay(d float64, limit time.Duration) time.Duration {
+	if limit > 0 && d > float64(limit) {
+		return limit
+	}
+	if d >= math.MaxInt64 {
+		return math.MaxInt64
+	}
+	return time.Duration(d)
+}
+
+// WithRetry retries a failed run up to attempts more times, waiting as b
+// says between tries. Retries belong to the same fire: they share its
+// RunInfo apart from Attempt and Fired, and the fire counts once in Stats
+// with the last try's error, its duration including the waits. A nil b
+// retries immediately.
+func WithRetry(attempts int, b Backoff) Option {
+	if b == nil {
+		b = ConstantBackoff{}
+	}
+	return func(t *Task) {
+		t.retries, t.backoff = attempts, b
+	}
+}
+
+// sleep waits for d, returning false if the task is stopped first.
+func (t *Task) sleep(d time.Duration) bool {
+	timer := time.NewTimer(d)
+	select {
+	case <-t.stopChan:
+		timer.Stop()
+		return false
+	case <-timer.C:
+		return true
+	}
+}
@@ -0,0 +1,53 @@
+package every
+
+import (
+	"context"
+	"sync"
+)
+
+// Batcher accumulates items added between fires and hands them to its
+// flush func as one batch on each fire. Fires with nothing pending do not
+// call flush.
+type Batcher[T any] struct {
+	*Task
+	mu    sync.Mutex
+	items []T
+}
+
+func NewBatcher[T any](interval string, flush func(ctx context.Context, items []T), opts ...Option) (*Batcher[T], error) {
+	b := &Batcher[T]{}
+	t, err := NewContextTask(interval, func(ctx context.Context) {
+		if items := b.take(); len(items) > 0 {
+			flush(ctx, items)
+		}
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	b.Task = t
+	return b, nil
+}
+
+func (b *Batcher[T]) Add(items ...T) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.items = append(b.items, items...)
+}
+
+func (b *Batcher[T]) Pending() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return len(b.items)
+}
+
+func (b *Batcher[T]) take() []T {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	items := b.items
+	b.items = nil
+	return items
+}
@@ -0,0 +1,72 @@
+package every
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// weeklySchedule fires once a week at a time of day.
+type weeklySchedule struct {
+	weekday time.Weekday
+	dailySchedule
+}
+
+func (s weeklySchedule) next(t time.Time) time.Time {
+	n := s.dailySchedule.next(t)
+	days := (int(s.weekday) - int(n.Weekday()) + 7) % 7
+	return time.Date(n.Year(), n.Month(), n.Day()+days, s.hour, s.minute, 0, 0, n.Location())
+}
+
+func (s weeklySchedule) String() string {
+	return fmt.Sprintf("next %s %02d:%02d", s.weekday, s.hour, s.minute)
+}
+
+// parseBoundary parses "next [<weekday>] [HH:MM]", such as "next 00:00"
+// or "next Monday 09:00". A weekday alone means midnight at its start.
+func parseBoundary(spec string) (schedule, error) {
+	fields := strings.Fields(strings.TrimPrefix(spec, "next"))
+	if len(fields) == 0 || len(fields) > 2 {
+		return nil, fmt.Errorf("%w: boundary %s", ErrInvalidValue, spec)
+	}
+
+	clock := "00:00"
+	if strings.Contains(fields[len(fields)-1], ":") {
+		clock, fields = fields[len(fields)-1], fields[:len(fields)-1]
+	}
+	daily, err := parseClock(spec, clock)
+	if err != nil {
+		return nil, err
+	}
+	if len(fields) == 0 {
+		return daily, nil
+	}
+	weekday, ok := parseWeekday(fields[0])
+	if !ok {
+		return nil, fmt.Errorf("%w: weekday %s", ErrInvalidValue, spec)
+	}
+	return weeklySchedule{weekday: weekday, dailySchedule: daily}, nil
+}
+
+func parseWeekday(s string) (time.Weekday, bool) {
+	s = strings.ToLower(s)
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		name := strings.ToLower(d.String())
+		if s == name || s == name[:3] {
+			return d, true
+		}
+	}
+	return 0, false
+}
+
+// Until returns how long it is from now until the next boundary described
+// by next, such as "next 00:00" or "next Monday 09:00", in local time.
+// The same specs work as task schedules, firing at every such boundary.
+func Until(next string) (time.Duration, error) {
+	s, err := parseBoundary(strings.TrimSpace(next))
+	if err != nil {
+		return 0, err
+	}
+	now := time.Now()
+	return s.next(now).Sub(now), nil
+}
@@ -0,0 +1,38 @@
+package every
+
+import "time"
+
+// WithCircuitBreaker opens the task's circuit once failures runs in a row
+// have failed: its fires are then skipped, as SkipCircuitOpen, until
+// cooldown has passed since the failure that opened it. The first fire
+// after that is a trial; if it fails too the circuit opens again for
+// another cooldown, and if it succeeds the task is back to normal. Each
+// opening sends EventCircuitOpen to the task's notifiers.
+func WithCircuitBreaker(failures int, cooldown time.Duration) Option {
+	return func(t *Task) {
+		t.breakerLimit, t.breakerOpen = failures, cooldown
+	}
+}
+
+// circuitOpen reports whether the circuit breaker holds back a fire at
+// now.
+func (t *Task) circuitOpen(now time.Time) bool {
+	return now.Before(t.openUntil)
+}
+
+// tripped counts a run that ended at end towards the circuit breaker.
+func (t *Task) tripped(end time.Time, err error) {
+	if err == nil {
+		t.failStreak = 0
+		return
+	}
+	t.failStreak++
+	if t.failStreak < t.breakerLimit {
+		return
+	}
+	t.openUntil = end.Add(t.breakerOpen)
+	t.log.Warn("circuit opened", "failures", t.failStreak, "until", t.openUntil)
+	if t.notifiers != nil {
+		t.notify(Event{Type: EventCircuitOpen, Time: end, Run: t.runs, Duration: t.breakerOpen, Err: err})
+	}
+}
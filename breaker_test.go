@@ -0,0 +1,70 @@
+package every
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	fail := true
+	var skipped []SkipReason
+	events := make(chanNotifier, 4)
+	task, err := NewErrorTask("1s", func(context.Context) error {
+		if fail {
+			return errors.New("down")
+		}
+		return nil
+	}, WithCircuitBreaker(2, time.Minute), WithNotifier(events, EventCircuitOpen),
+		OnSkip(func(r SkipReason) { skipped = append(skipped, r) }))
+	if err != nil {
+		t.Fatal(err)
+	}
+	task.timer = timers.get()
+	task.begin()
+	defer func() {
+		timers.put(task.timer)
+		task.closeOutbox()
+		task.cancel()
+	}()
+	fire := func(now time.Time) {
+		task.due = now
+		task.fire(now)
+	}
+
+	now := time.Now()
+	fire(now)
+	fire(now)
+	if task.runs != 2 {
+		t.Fatalf("%d runs before the circuit opened, want 2", task.runs)
+	}
+	select {
+	case e := <-events:
+		if e.Duration != time.Minute || e.Err == nil {
+			t.Errorf("circuit open event %+v", e)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("no EventCircuitOpen")
+	}
+
+	fire(time.Now())
+	if task.runs != 2 || len(skipped) != 1 || skipped[0] != SkipCircuitOpen {
+		t.Errorf("open circuit: %d runs, skips %v", task.runs, skipped)
+	}
+
+	// A failed trial opens the circuit again; a successful one closes it.
+	task.openUntil = time.Now()
+	fire(time.Now())
+	if task.runs != 3 || !task.circuitOpen(time.Now()) {
+		t.Errorf("failed trial: %d runs, open %v", task.runs, task.circuitOpen(time.Now()))
+	}
+	task.openUntil = time.Now()
+	fail = false
+	fire(time.Now())
+	fail = true
+	fire(time.Now())
+	if task.runs != 5 || task.circuitOpen(time.Now()) {
+		t.Errorf("after a successful trial: %d runs, open %v", task.runs, task.circuitOpen(time.Now()))
+	}
+}
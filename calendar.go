@@ -0,0 +1,158 @@
+package every
+
+import (
+	"fmt"
+	"time"
+)
+
+// maxHolidaySkip bounds how far ahead a task searches for a fire time
+// that is not a holiday before giving up.
+const maxHolidaySkip = 366 * 24 * time.Hour
+
+type Calendar interface {
+	IsHoliday(date time.Time) bool
+}
+
+type civilDate struct {
+	year  int
+	month time.Month
+	day   int
+}
+
+func dateOf(t time.Time) civilDate {
+	y, m, d := t.Date()
+	return civilDate{y, m, d}
+}
+
+// StaticCalendar is a fixed set of holidays. Dates compare by year, month
+// and day in the location they are given in, as does every date passed to
+// IsHoliday.
+type StaticCalendar struct {
+	dates map[civilDate]struct{}
+}
+
+func NewStaticCalendar(dates ...time.Time) *StaticCalendar {
+	c := &StaticCalendar{dates: make(map[civilDate]struct{}, len(dates))}
+	for _, d := range dates {
+		c.dates[dateOf(d)] = struct{}{}
+	}
+	return c
+}
+
+func (c *StaticCalendar) IsHoliday(date time.Time) bool {
+	_, ok := c.dates[dateOf(date)]
+	return ok
+}
+
+func WithCalendar(c Calendar) Option {
+	return func(t *Task) {
+		t.calendar = c
+	}
+}
+
+// RollPolicy decides what happens to a fire that lands on a day off: a
+// holiday, or a weekend for business-day tasks.
+type RollPolicy int
+
+const (
+	// RollSkip drops the fire.
+	RollSkip RollPolicy = iota
+	// RollForward moves the fire to the next business day at the same time
+	// of day, unless the schedule fires earlier than that anyway.
+	RollForward
+	// RollBackward moves the fire to the previous business day at the same
+	// time of day, or drops it if that has already passed.
+	RollBackward
+)
+
+func (p RollPolicy) String() string {
+	switch p {
+	case RollSkip:
+		return "skip"
+	case RollForward:
+		return "forward"
+	case RollBackward:
+		return "backward"
+	}
+	return "unknown"
+}
+
+func (p RollPolicy) MarshalText() ([]byte, error) {
+	return []byte(p.String()), nil
+}
+
+func (p *RollPolicy) UnmarshalText(text []byte) error {
+	for _, q := range []RollPolicy{RollSkip, RollForward, RollBackward} {
+		if q.String() == string(text) {
+			*p = q
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: roll policy %s", ErrInvalidValue, text)
+}
+
+// WithBusinessDays treats Saturdays and Sundays as days off in addition to
+// any calendar holidays.
+func WithBusinessDays() Option {
+	return func(t *Task) {
+		t.businessDays = true
+	}
+}
+
+func WithRollPolicy(p RollPolicy) Option {
+	return func(t *Task) {
+		t.roll = p
+	}
+}
+
+func (t *Task) isDayOff(d time.Time) bool {
+	if t.calendar != nil && t.calendar.IsHoliday(d) {
+		return true
+	}
+	if _, ok := t.schedule.(businessSchedule); ok || t.businessDays {
+		return d.Weekday() == time.Saturday || d.Weekday() == time.Sunday
+	}
+	return false
+}
+
+// rollDay moves d one day at a time in direction dir until it reaches a
+// business day, or returns the zero time if none is found.
+func (t *Task) rollDay(d time.Time, dir int) time.Time {
+	for i := 0; i < int(maxHolidaySkip/(24*time.Hour)); i++ {
+		d = time.Date(d.Year(), d.Month(), d.Day()+dir, d.Hour(), d.Minute(), d.Second(), 0, d.Location())
+		if !t.isDayOff(d) {
+			return d
+		}
+	}
+	return time.Time{}
+}
+
+// nextWorking returns the first fire time of the task's schedule after now
+// once days off have been rolled, or the zero time if there is none. It
+// also returns how many fires were dropped along the way.
+func (t *Task) nextWorking(now time.Time) (time.Time, int) {
+	next, dropped := t.scheduled(now), 0
+	for !next.IsZero() && t.isDayOff(next) {
+		if next.Sub(now) > maxHolidaySkip {
+			return time.Time{}, dropped
+		}
+
+		switch t.roll {
+		case RollForward:
+			rolled := t.rollDay(next, 1)
+			for n := t.scheduled(next); !n.IsZero() && n.Before(rolled); n = t.scheduled(n) {
+				if !t.isDayOff(n) {
+					return n, dropped
+				}
+			}
+			return rolled, dropped
+		case RollBackward:
+			if rolled := t.rollDay(next, -1); rolled.After(now) {
+				return rolled, dropped
+			}
+		}
+		next = t.scheduled(next)
+		dropped++
+	}
+	return next, dropped
+}
@@ -0,0 +1,37 @@
+package every
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBusinessDays(t *testing.T) {
+	day := func(s string) time.Time {
+		d, _ := time.Parse("2006-01-02 15:04", s)
+		return d
+	}
+	holiday := NewStaticCalendar(day("2024-03-04 00:00"))
+	for _, tc := range []struct {
+		name       string
+		spec       string
+		opts       []Option
+		from, want string
+	}{
+		{"weekend skipped", "business-daily@09:00", nil, "2024-03-01 10:00", "2024-03-04 09:00"},
+		{"holiday skipped", "business-daily@09:00", []Option{WithCalendar(holiday)}, "2024-03-01 10:00", "2024-03-05 09:00"},
+		{"forward onto a fire", "daily@09:00", []Option{WithBusinessDays(), WithRollPolicy(RollForward)}, "2024-03-01 10:00", "2024-03-04 09:00"},
+		{"skip", "0 9 1 * *", []Option{WithBusinessDays()}, "2024-05-15 00:00", "2024-07-01 09:00"},
+		{"forward", "0 9 1 * *", []Option{WithBusinessDays(), WithRollPolicy(RollForward)}, "2024-05-15 00:00", "2024-06-03 09:00"},
+		{"backward", "0 9 1 * *", []Option{WithBusinessDays(), WithRollPolicy(RollBackward)}, "2024-05-15 00:00", "2024-05-31 09:00"},
+		{"backward already past", "0 9 1 * *", []Option{WithBusinessDays(), WithRollPolicy(RollBackward)}, "2024-05-31 10:00", "2024-07-01 09:00"},
+		{"holiday on a plain schedule", "daily@09:00", []Option{WithCalendar(holiday)}, "2024-03-03 10:00", "2024-03-05 09:00"},
+	} {
+		task, err := NewTask(tc.spec, func() {}, tc.opts...)
+		if err != nil {
+			t.Fatalf("%s: %v", tc.name, err)
+		}
+		if got := task.next(day(tc.from)); !got.Equal(day(tc.want)) {
+			t.Errorf("%s: next after %s = %s, want %s", tc.name, tc.from, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package every
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Chunker is a long job split into chunks. Next does one chunk and reports
+// whether the job is complete.
+type Chunker interface {
+	Next(ctx context.Context) (done bool, err error)
+}
+
+// ChunkedTask works through a Chunker a slice at a time, so one giant job
+// holds a pool slot for at most one slice per fire instead of for hours.
+type ChunkedTask struct {
+	*Task
+	job      Chunker
+	budget   time.Duration
+	complete chan struct{}
+	once     sync.Once
+}
+
+// NewChunkedTask calls job.Next on each fire until budget has been spent,
+// the job is done or a chunk fails; a budget of zero or less runs one chunk
+// per fire. A failed chunk fails the run and is tried again on the next
+// fire. Once the job is done, later fires do nothing.
+func NewChunkedTask(interval string, job Chunker, budget time.Duration, opts ...Option) (*ChunkedTask, error) {
+	c := &ChunkedTask{job: job, budget: budget, complete: make(chan struct{})}
+	t, err := NewErrorTask(interval, c.slice, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	c.Task = t
+	return c, nil
+}
+
+// Complete returns a channel that is closed once the job reports done.
+func (c *ChunkedTask) Complete() <-chan struct{} {
+	return c.complete
+}
+
+func (c *ChunkedTask) slice(ctx context.Context) error {
+	select {
+	case <-c.complete:
+		return nil
+	default:
+	}
+
+	start := time.Now()
+	for {
+		done, err := c.job.Next(ctx)
+		if err != nil {
+			return err
+		}
+		if done {
+			c.once.Do(func() { close(c.complete) })
+			return nil
+		}
+		if ctx.Err() != nil || time.Since(start) >= c.budget {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package every
+
+import "time"
+
+// clockStepThreshold is how far the wall clock may drift from the
+// monotonic clock over one wait before it counts as a step.
+const clockStepThreshold = 5 * time.Second
+
+// checkClock compares how much wall and monotonic time passed since the
+// timer was armed, as read into armedWall and armed. Waits are measured on
+// the monotonic clock, so interval tasks keep their cadence through a step
+// and neither storm nor stall; the step is logged and reported so calendar
+// schedules, which follow the wall clock, can be checked.
+func (t *Task) checkClock(now time.Time) {
+	if t.armed.IsZero() {
+		return
+	}
+	step := now.Round(0).Sub(t.armedWall) - now.Sub(t.armed)
+	if step.Abs() < clockStepThreshold {
+		return
+	}
+
+	t.armed = time.Time{}
+	t.log.Warn("wall clock stepped", "step", step)
+	if t.notifiers != nil {
+		t.notify(Event{Type: EventClockStep, Time: now, Duration: step})
+	}
+}
@@ -0,0 +1,62 @@
+package every
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+type chanNotifier chan Event
+
+func (c chanNotifier) Notify(_ context.Context, e Event) error {
+	c <- e
+	return nil
+}
+
+// stepClock makes it look to task as if the wall clock jumped by step
+// during the second since its timer was armed.
+func stepClock(task *Task, step time.Duration) time.Time {
+	now := time.Now()
+	task.armed = now.Add(-time.Second)
+	task.armedWall = task.armed.Round(0).Add(-step)
+	return now
+}
+
+func TestClockStep(t *testing.T) {
+	for _, step := range []time.Duration{time.Hour, -time.Hour} {
+		events := make(chanNotifier, 1)
+		task := firing(t, WithNotifier(events, EventClockStep))
+		now := stepClock(task, step)
+		task.due = now
+		task.fire(now)
+
+		select {
+		case e := <-events:
+			if e.Duration.Round(time.Second) != step {
+				t.Errorf("step of %v reported as %v", step, e.Duration)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("step of %v not reported", step)
+		}
+		// The interval is measured on the monotonic clock, so the step
+		// neither makes the task fire again at once nor holds it back.
+		if task.runs != 1 {
+			t.Errorf("step of %v: %d runs, want 1", step, task.runs)
+		}
+		if wait := task.wait(task.due); wait <= 0 || wait > time.Second {
+			t.Errorf("step of %v: next fire in %v, want within the 1s interval", step, wait)
+		}
+	}
+}
+
+func TestClockWithoutStep(t *testing.T) {
+	events := make(chanNotifier, 1)
+	task := firing(t, WithNotifier(events, EventClockStep))
+	now := stepClock(task, time.Second)
+	task.checkClock(now)
+	select {
+	case e := <-events:
+		t.Errorf("drift under the threshold reported: %v", e)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
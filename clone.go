@@ -0,0 +1,58 @@
+package every
+
+import (
+	"context"
+	"errors"
+	"slices"
+)
+
+var errCloneTriggered = errors.New("tasks behind a Debouncer or Throttler cannot be cloned")
+
+// WithSchedule replaces the schedule spec the task is created with. It is
+// mostly useful with Clone.
+func WithSchedule(spec string) Option {
+	return func(t *Task) {
+		t.spec = spec
+	}
+}
+
+// WithFunc replaces the task's func, for instance to clone a task with a
+// stand-in func in tests.
+func WithFunc(fn func(ctx context.Context) error) Option {
+	return func(t *Task) {
+		t.taskFunc = fn
+	}
+}
+
+// Clone returns a new, unstarted task with t's func, schedule and options,
+// with opts applied on top, for variants of one job such as a task per
+// tenant. The clone has its own stats and lifetime: it does not inherit a
+// WithContext context and joins a Scheduler only once added. Clones of a
+// Batcher's task share its pending items.
+func (t *Task) Clone(opts ...Option) (*Task, error) {
+	if t.events != nil {
+		return nil, errCloneTriggered
+	}
+
+	c := newTask(t.currentSchedule(), t.taskFunc)
+	c.name = t.name
+	c.tags = slices.Clone(t.tags)
+	c.funcName = t.funcName
+	c.parser = t.parser
+	c.logger = t.logger
+	c.settings = t.settings
+	c.ramp = slices.Clone(t.ramp)
+	c.notifiers = slices.Clone(t.notifiers)
+	c.pool, c.supervisor = nil, nil
+	c.opts = slices.Clone(t.opts)
+	c.apply(opts)
+
+	if c.spec != "" {
+		s, err := c.parser.parse(c.spec)
+		if err != nil {
+			return nil, err
+		}
+		c.schedule = s
+	}
+	return c, nil
+}
@@ -0,0 +1,159 @@
+// Command everybench runs many tasks for a while and reports how well they
+// keep time, to check a scheduler setup against a workload before relying
+// on it:
+//
+//	everybench -tasks 10000 -interval 1s -duration 5m -afterfunc
+//
+// Every report line covers the runs since the previous one: how late they
+// started after they were due (lateness), how far the latest run of each
+// task is from where a perfect clock would have put it (drift), and the
+// process's goroutines and allocations.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"runtime"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/daifiyum/every"
+)
+
+func main() {
+	var (
+		tasks     = flag.Int("tasks", 1000, "number of tasks")
+		interval  = flag.String("interval", "1s", "task interval, in every's syntax")
+		duration  = flag.Duration("duration", time.Minute, "how long to run")
+		report    = flag.Duration("report", 10*time.Second, "how often to report")
+		work      = flag.Duration("work", 0, "how long each run takes")
+		afterFunc = flag.Bool("afterfunc", false, "run tasks on time.AfterFunc instead of a goroutine each")
+		poolSize  = flag.Int("pool", 0, "share a pool of this many run slots between the tasks")
+	)
+	flag.Parse()
+
+	var opts []every.Option
+	if *afterFunc {
+		opts = append(opts, every.WithAfterFunc())
+	}
+
+	var rec recorder
+	s := every.NewScheduler()
+	if *poolSize > 0 {
+		s.SetPoolSize(*poolSize)
+	}
+	for i := range *tasks {
+		t, err := every.NewContextTask(*interval, rec.run(*work), append(opts, every.WithName(fmt.Sprintf("bench-%d", i)))...)
+		if err != nil {
+			log.Fatal(err)
+		}
+		s.Add(t)
+	}
+
+	const row = "%8s %8v %10v %10v %10v %10v %10v %10v %10v\n"
+	fmt.Printf(row, "elapsed", "runs", "late p50", "late p99", "late max", "drift max", "goroutines", "allocs/run", "heap")
+	start := time.Now()
+	s.StartAll()
+
+	ticker := time.NewTicker(*report)
+	defer ticker.Stop()
+	deadline := time.After(*duration)
+	var prev runtime.MemStats
+	runtime.ReadMemStats(&prev)
+	for done := false; !done; {
+		select {
+		case <-ticker.C:
+		case <-deadline:
+			done = true
+		}
+		var mem runtime.MemStats
+		runtime.ReadMemStats(&mem)
+		late, drift := rec.take()
+		if done && len(late) == 0 {
+			break
+		}
+		allocs := 0.0
+		if len(late) > 0 {
+			allocs = float64(mem.Mallocs-prev.Mallocs) / float64(len(late))
+		}
+		prev = mem
+		fmt.Printf(row, time.Since(start).Round(time.Second), len(late),
+			round(percentile(late, 0.5)), round(percentile(late, 0.99)), round(percentile(late, 1)), round(drift),
+			runtime.NumGoroutine(), fmt.Sprintf("%.1f", allocs), fmt.Sprintf("%.1fMiB", float64(mem.HeapAlloc)/(1<<20)))
+	}
+	s.StopAll()
+}
+
+// recorder collects the timing of runs between reports.
+type recorder struct {
+	mu    sync.Mutex
+	late  []time.Duration
+	drift time.Duration
+}
+
+func (r *recorder) run(work time.Duration) func(ctx context.Context) {
+	var first, prev time.Time
+	return func(ctx context.Context) {
+		fired := time.Now()
+		info, _ := every.RunInfoFrom(ctx)
+
+		r.mu.Lock()
+		r.late = append(r.late, fired.Sub(info.Scheduled))
+		if first.IsZero() {
+			first = fired
+		} else {
+			// A perfect clock fires run n exactly n-1 periods after the
+			// first. Interval schedules count from the previous run, so
+			// lateness adds up into drift.
+			ideal := first.Add(time.Duration(info.ID-1) * info.Scheduled.Sub(info.Previous))
+			if info.Previous.Equal(prev) {
+				r.drift = max(r.drift, abs(fired.Sub(ideal)))
+			}
+		}
+		prev = info.Scheduled
+		r.mu.Unlock()
+
+		if work > 0 {
+			time.Sleep(work)
+		}
+	}
+}
+
+// take returns the lateness of the runs since the last call, sorted, and
+// the largest drift seen so far.
+func (r *recorder) take() ([]time.Duration, time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	late := r.late
+	r.late = nil
+	slices.Sort(late)
+	return late, r.drift
+}
+
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	return sorted[int(p*float64(len(sorted)-1))]
+}
+
+// round trims d to three significant digits for the report.
+func round(d time.Duration) time.Duration {
+	for unit := time.Duration(1); unit < time.Hour; unit *= 10 {
+		if d < 1000*unit {
+			return d.Round(unit)
+		}
+	}
+	return d.Round(time.Second)
+}
+
+func abs(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+	return d
+}
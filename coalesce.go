@@ -0,0 +1,33 @@
+package every
+
+import "time"
+
+// SetCoalescing lets the Scheduler's tasks fire up to window late so that
+// fires landing close together share one wakeup, which saves power on
+// laptops and edge devices running many tasks. Each fire is put off to the
+// next multiple of window since the Unix epoch, so a window of 250ms turns
+// fires at 10.05s and 10.2s into a single wakeup at 10.25s. Tasks with a
+// positive WithPriority keep to their exact times. A window of 0 turns
+// coalescing off. Like SetPoolSize, it must be called before the tasks are
+// started.
+func (s *Scheduler) SetCoalescing(window time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.coalesce = window
+	for _, t := range s.tasks {
+		t.coalesce = window
+	}
+}
+
+// coalesced returns when the timer for a fire due at should go off.
+func (t *Task) coalesced(due time.Time) time.Time {
+	w := t.coalesce
+	if w <= 0 || t.priority > 0 {
+		return due
+	}
+	if r := time.Duration(due.UnixNano()) % w; r != 0 {
+		return due.Add(w - r)
+	}
+	return due
+}
@@ -0,0 +1,42 @@
+package every
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// Sequence returns a task func that calls fns in order with the run's
+// context, stopping at the first error or once the context is done.
+func Sequence(fns ...func(ctx context.Context) error) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		for _, fn := range fns {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			if err := fn(ctx); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// Parallel returns a task func that calls fns concurrently with the run's
+// context and waits for all of them, returning their errors joined with
+// errors.Join.
+func Parallel(fns ...func(ctx context.Context) error) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		errs := make([]error, len(fns))
+		var wg sync.WaitGroup
+		for i, fn := range fns {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				errs[i] = fn(ctx)
+			}()
+		}
+		wg.Wait()
+		return errors.Join(errs...)
+	}
+}
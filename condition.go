@@ -0,0 +1,28 @@
+package every
+
+import "time"
+
+// WithCondition checks fn at every fire and skips the run when it returns
+// false, counting a SkipCondition skip, so tasks can honour feature flags
+// or maintenance windows without changing the task func.
+func WithCondition(fn func() bool) Option {
+	return func(t *Task) {
+		t.condition = fn
+	}
+}
+
+// passOver moves on from a fire that was skipped at now for reason without
+// running. Triggered tasks wait for the next trigger.
+func (t *Task) passOver(now time.Time, reason SkipReason) {
+	var dropped int
+	if t.events == nil {
+		t.due, dropped = t.following(now)
+	} else {
+		t.due = time.Time{}
+	}
+	t.arm()
+	t.setState(StateWaiting, t.due)
+	t.skip(reason, 1)
+	t.skip(SkipDayOff, dropped)
+	t.traceNext()
+}
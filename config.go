@@ -0,0 +1,122 @@
+package every
+
+import (
+	"slices"
+	"time"
+)
+
+// TaskConfig is a copy of a task's effective settings, for tests and admin
+// pages to check or show. Settings that are funcs, such as hooks and
+// conditions, are reported only by whether they are set. Changing a
+// TaskConfig has no effect on the task.
+type TaskConfig struct {
+	Name      string
+	Namespace string
+	Schedule  string
+	Tags      []string
+	Seconds   bool
+	Strict    bool
+
+	Misfire       MisfirePolicy
+	BusinessDays  bool
+	Calendar      bool
+	Roll          RollPolicy
+	Splay         time.Duration
+	Ramp          []Stage
+	Cooldown      time.Duration
+	MaxWait       time.Duration
+	Jitter        Jitter
+	Rebase        RebasePolicy
+	Queue         int
+	QueueOverflow OverflowPolicy
+
+	Timeout       time.Duration
+	Retries       int
+	Backoff       Backoff
+	Warmup        bool
+	FinalRun      bool
+	PanicLimit    int
+	PanicWindow   time.Duration
+	BreakerLimit  int
+	BreakerOpen   time.Duration
+	Weight        float64
+	Priority      int
+	Preemption    bool
+	LoadShedding  bool
+	ShedThreshold float64
+	DedupWindow   time.Duration
+	Resources     Resources
+	Delivery      Delivery
+	Phase, Phases int
+	AsyncRuns     bool
+	MissingFunc   MissingFuncPolicy
+
+	AfterFunc     bool
+	Supervised    bool
+	Condition     bool
+	Trigger       bool
+	Notifiers     int
+	Audit         bool
+	History       bool
+	Heartbeat     bool
+	JobStore      bool
+	ProfileLabels bool
+}
+
+// Config returns the task's effective settings.
+func (t *Task) Config() TaskConfig {
+	return TaskConfig{
+		Name:      t.name,
+		Namespace: t.Namespace(),
+		Schedule:  t.Schedule(),
+		Tags:      t.Tags(),
+		Seconds:   t.parser.seconds,
+		Strict:    t.parser.strict,
+
+		Misfire:       t.misfire,
+		BusinessDays:  t.businessDays,
+		Calendar:      t.calendar != nil,
+		Roll:          t.roll,
+		Splay:         t.splay,
+		Ramp:          slices.Clone(t.ramp),
+		Cooldown:      t.cooldown,
+		MaxWait:       t.maxWait,
+		Jitter:        t.jitter,
+		Rebase:        t.rebase,
+		Queue:         t.queueDepth,
+		QueueOverflow: t.queueFull,
+
+		Timeout:       t.timeout,
+		Retries:       t.retries,
+		Backoff:       t.backoff,
+		Warmup:        t.warmup != nil,
+		FinalRun:      t.final,
+		PanicLimit:    t.panicLimit,
+		PanicWindow:   t.panicWindow,
+		BreakerLimit:  t.breakerLimit,
+		BreakerOpen:   t.breakerOpen,
+		Weight:        t.weight(),
+		Priority:      t.priority,
+		Preemption:    t.preempting,
+		LoadShedding:  t.shedLoad != nil,
+		ShedThreshold: t.shedAbove,
+		DedupWindow:   t.dedupWindow,
+		Resources:     t.resources,
+		Delivery:      t.delivery,
+		Phase:         t.phase,
+		Phases:        t.phases,
+		AsyncRuns:     t.async,
+		MissingFunc:   t.missingFunc,
+
+		AfterFunc:     t.afterFunc,
+		Supervised:    t.supervisor != nil,
+		Condition:     t.condition != nil,
+		Trigger:       t.extTrigger != nil,
+		Notifiers:     len(t.notifiers),
+		Audit:         t.audit != nil,
+		History:       t.historySink != nil,
+		Heartbeat:     t.heartbeat != nil,
+		JobStore:      t.store != nil,
+		ProfileLabels: t.profileLabels,
+	}
+}
@@ -0,0 +1,24 @@
+package every
+
+import "context"
+
+// WithContext ties the task's lifetime to ctx: once ctx is cancelled or its
+// deadline passes, the task stops as if Stop had been called. The context
+// passed to each run is derived from ctx, so its values are visible there.
+func WithContext(ctx context.Context) Option {
+	return func(t *Task) {
+		t.cancel()
+		t.ctx, t.cancel = context.WithCancel(ctx)
+		t.runCtx = context.WithValue(t.ctx, taskKey{}, &t.current)
+		if t.unwatch != nil {
+			t.unwatch()
+		}
+		t.unwatch = context.AfterFunc(ctx, t.Stop)
+	}
+}
+
+// NewTaskWithContext is like NewContextTask with WithContext(ctx): the task
+// stops when ctx is done, and each run's context is a child of ctx.
+func NewTaskWithContext(ctx context.Context, interval string, task func(ctx context.Context), opts ...Option) (*Task, error) {
+	return NewContextTask(interval, task, append([]Option{WithContext(ctx)}, opts...)...)
+}
@@ -0,0 +1,20 @@
+package every
+
+import "time"
+
+// WithCooldown keeps a task quiet for at least d after any failed run,
+// whatever its schedule or triggers say. It only delays fires; it never
+// adds any.
+func WithCooldown(d time.Duration) Option {
+	return func(t *Task) {
+		t.cooldown = d
+	}
+}
+
+// coolDown pushes next past the quiet period following the last failure.
+func (t *Task) coolDown(next time.Time) time.Time {
+	if !next.IsZero() && next.Before(t.quietUntil) {
+		return t.quietUntil
+	}
+	return next
+}
@@ -0,0 +1,184 @@
+package every
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type cronField struct {
+	min, max int
+	names    map[string]int
+}
+
+var cronFields = [5]cronField{
+	{min: 0, max: 59},
+	{min: 0, max: 23},
+	{min: 1, max: 31},
+	{min: 1, max: 12, names: map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}},
+	{min: 0, max: 7, names: map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}},
+}
+
+var secondField = cronField{min: 0, max: 59}
+
+type cronSchedule struct {
+	spec                                  string
+	second, minute, hour, dom, month, dow uint64
+	domStar, dowStar, withSeconds         bool
+}
+
+// parseCron parses a five-field cron expression, or a six-field one with a
+// leading seconds column when withSeconds is set.
+func parseCron(spec string, withSeconds bool) (*cronSchedule, error) {
+	fields := strings.Fields(spec)
+	dateFields, second := fields, uint64(1)
+	if withSeconds {
+		if len(fields) != len(cronFields)+1 {
+			return nil, fmt.Errorf("%w: cron expression %s has %d fields, want %d", ErrInvalidSchedule, spec, len(fields), len(cronFields)+1)
+		}
+		set, err := parseCronField(fields[0], secondField)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cron expression: %s: %w", spec, err)
+		}
+		dateFields, second = fields[1:], set
+	}
+	if len(dateFields) != len(cronFields) {
+		return nil, fmt.Errorf("%w: cron expression %s has %d fields, want %d", ErrInvalidSchedule, spec, len(dateFields), len(cronFields))
+	}
+
+	var sets [5]uint64
+	for i, field := range dateFields {
+		set, err := parseCronField(field, cronFields[i])
+		if err != nil {
+			return nil, fmt.Errorf("invalid cron expression: %s: %w", spec, err)
+		}
+		sets[i] = set
+	}
+
+	// Sunday may be written as 0 or 7.
+	if sets[4]&(1<<7) != 0 {
+		sets[4] = sets[4]&^(1<<7) | 1
+	}
+
+	cs := &cronSchedule{
+		spec:        strings.Join(fields, " "),
+		second:      second,
+		minute:      sets[0],
+		hour:        sets[1],
+		dom:         sets[2],
+		month:       sets[3],
+		dow:         sets[4],
+		domStar:     dateFields[2] == "*" || dateFields[2] == "?",
+		dowStar:     dateFields[4] == "*" || dateFields[4] == "?",
+		withSeconds: withSeconds,
+	}
+	if cs.next(time.Now()).IsZero() {
+		return nil, fmt.Errorf("%w: cron expression never fires: %s", ErrInvalidSchedule, spec)
+	}
+	return cs, nil
+}
+
+func parseCronField(field string, f cronField) (uint64, error) {
+	var set uint64
+	for _, item := range strings.Split(field, ",") {
+		lo, hi, step := f.min, f.max, 1
+
+		rng, stepStr, hasStep := strings.Cut(item, "/")
+		if hasStep {
+			n, err := strconv.Atoi(stepStr)
+			if err != nil || n <= 0 {
+				return 0, fmt.Errorf("%w: step %s", ErrInvalidValue, item)
+			}
+			step = n
+		}
+
+		if rng != "*" && rng != "?" {
+			loStr, hiStr, isRange := strings.Cut(rng, "-")
+			var err error
+			if lo, err = f.value(loStr); err != nil {
+				return 0, err
+			}
+			switch {
+			case isRange:
+				if hi, err = f.value(hiStr); err != nil {
+					return 0, err
+				}
+			case !hasStep:
+				hi = lo
+			}
+		}
+
+		if lo > hi {
+			return 0, fmt.Errorf("%w: range %s", ErrInvalidValue, item)
+		}
+		for v := lo; v <= hi; v += step {
+			set |= 1 << v
+		}
+	}
+	return set, nil
+}
+
+func (f cronField) value(s string) (int, error) {
+	if v, ok := f.names[strings.ToLower(s)]; ok {
+		return v, nil
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil || v < f.min || v > f.max {
+		return 0, fmt.Errorf("%w: %s", ErrInvalidValue, s)
+	}
+	return v, nil
+}
+
+func (s *cronSchedule) String() string {
+	return s.spec
+}
+
+func (s *cronSchedule) next(t time.Time) time.Time {
+	loc := t.Location()
+	t = t.Round(0).Truncate(time.Second).Add(time.Second)
+
+	// Every matching time recurs within a few years (Feb 29 on a given
+	// weekday is the worst case), so give up past that.
+	limit := t.AddDate(5, 0, 0)
+	for t.Before(limit) {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !s.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+			continue
+		}
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Truncate(time.Minute).Add(time.Minute)
+			continue
+		}
+		if s.second&(1<<uint(t.Second())) == 0 {
+			t = t.Add(time.Second)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+// dayMatches follows cron's rule that when both day-of-month and
+// day-of-week are restricted, a day matching either one qualifies.
+func (s *cronSchedule) dayMatches(t time.Time) bool {
+	dom := s.dom&(1<<uint(t.Day())) != 0
+	dow := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.domStar || s.dowStar {
+		return dom && dow
+	}
+	return dom || dow
+}
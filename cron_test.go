@@ -0,0 +1,55 @@
+package every
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCronNext(t *testing.T) {
+	for _, tc := range []struct {
+		spec       string
+		from, want string
+	}{
+		{"*/15 * * * *", "2024-03-01 10:07", "2024-03-01 10:15"},
+		{"5-10/2 * * * *", "2024-03-01 10:06", "2024-03-01 10:07"},
+		{"0 9-17 * * 1-5", "2024-03-01 17:30", "2024-03-04 09:00"},
+		{"30 2 1 * *", "2024-01-15 00:00", "2024-02-01 02:30"},
+		// Day of month and day of week both restricted: either matches.
+		{"0 0 13 * 5", "2024-09-01 00:00", "2024-09-06 00:00"},
+		// Only one restricted: it alone decides.
+		{"0 0 13 * *", "2024-09-01 00:00", "2024-09-13 00:00"},
+		{"0 0 * * 7", "2024-03-02 12:00", "2024-03-03 00:00"},
+		{"0 12 * jan,JUL sun", "2024-02-01 00:00", "2024-07-07 12:00"},
+		{"0 0 29 2 *", "2024-03-01 00:00", "2028-02-29 00:00"},
+		{"0 0 * * *", "2024-12-31 23:59", "2025-01-01 00:00"},
+	} {
+		s, err := parseSchedule(tc.spec)
+		if err != nil {
+			t.Errorf("%q: %v", tc.spec, err)
+			continue
+		}
+		from, _ := time.Parse("2006-01-02 15:04", tc.from)
+		want, _ := time.Parse("2006-01-02 15:04", tc.want)
+		if got := s.next(from); !got.Equal(want) {
+			t.Errorf("%q after %s = %s, want %s", tc.spec, tc.from, got, want)
+		}
+	}
+}
+
+func TestCronInvalid(t *testing.T) {
+	for _, spec := range []string{
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"5-1 * * * *",
+		"*/0 * * * *",
+		"* * * foo *",
+		"* * * * 8",
+	} {
+		if _, err := parseSchedule(spec); err == nil {
+			t.Errorf("%q parsed", spec)
+		}
+	}
+}
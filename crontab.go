@@ -0,0 +1,164 @@
+package every
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os/exec"
+	"runtime"
+	"strings"
+	"unicode"
+)
+
+// Registry maps names to task funcs, for TaskDefinition funcs and
+// crontab commands. Lookup has the signature ImportCrontab resolves
+// commands with, so a crontab can name funcs in a Registry.
+type Registry map[string]func()
+
+// Lookup returns the func registered as name, or nil for a nil one.
+func (r Registry) Lookup(name string) (func(ctx context.Context) error, error) {
+	fn, ok := r[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown function: %s", name)
+	}
+	if fn == nil {
+		return nil, nil
+	}
+	return func(context.Context) error {
+		fn()
+		return nil
+	}, nil
+}
+
+// ShellCommand resolves a crontab command into a func that runs it through
+// the system shell, the way cron itself would. The run fails if the
+// command exits non-zero, and the command is killed if the run's context
+// is cancelled, as on Stop or a WithTimeout deadline.
+func ShellCommand(command string) (func(ctx context.Context) error, error) {
+	shell, flag := "sh", "-c"
+	if runtime.GOOS == "windows" {
+		shell, flag = "cmd", "/C"
+	}
+	if _, err := exec.LookPath(shell); err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context) error {
+		return exec.CommandContext(ctx, shell, flag, command).Run()
+	}, nil
+}
+
+// ImportCrontab reads crontab lines from r and adds one task per entry,
+// using resolve to turn each command into a task func. Blank lines,
+// comments and environment assignments are skipped, and so are @reboot
+// entries, which have no schedule, with a warning to the Scheduler's
+// logger. A % in a command, which cron turns into a newline and standard
+// input, is an error unless escaped as \%. Nothing is added unless every
+// other line parses.
+func (s *Scheduler) ImportCrontab(r io.Reader, resolve func(command string) (func(ctx context.Context) error, error)) ([]*Task, error) {
+	s.mu.Lock()
+	logger := s.logger
+	s.mu.Unlock()
+	if logger == nil {
+		logger = discardLogger
+	}
+
+	var tasks []*Task
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || line[0] == '#' || isEnvLine(line) {
+			continue
+		}
+		if f := strings.Fields(line); f[0] == "@reboot" {
+			logger.Warn("crontab @reboot entry skipped", "line", n, "command", strings.TrimSpace(strings.TrimPrefix(line, f[0])))
+			continue
+		}
+
+		spec, command := splitCrontabLine(line)
+		if command == "" {
+			return nil, fmt.Errorf("line %d: missing command", n)
+		}
+		command, ok := unescapePercent(command)
+		if !ok {
+			return nil, fmt.Errorf("line %d: unescaped %% in command, which cron would turn into standard input", n)
+		}
+		sched, err := parseSchedule(spec)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", n, err)
+		}
+		fn, err := resolve(command)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", n, err)
+		}
+
+		t := newTask(sched, fn)
+		t.name = command
+		tasks = append(tasks, t)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, t := range tasks {
+		s.Add(t)
+	}
+	return tasks, nil
+}
+
+// isEnvLine reports whether line assigns an environment variable, as in
+// "PATH=/usr/bin" or "MAILTO = ops@example.com".
+func isEnvLine(line string) bool {
+	name, _, ok := strings.Cut(line, "=")
+	name = strings.TrimSpace(name)
+	if !ok || name == "" {
+		return false
+	}
+	for _, r := range name {
+		if r != '_' && !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// unescapePercent replaces each \% in command with %, reporting false if
+// command has a % that is not escaped.
+func unescapePercent(command string) (string, bool) {
+	var b strings.Builder
+	for {
+		i := strings.IndexByte(command, '%')
+		if i < 0 {
+			b.WriteString(command)
+			return b.String(), true
+		}
+		if i == 0 || command[i-1] != '\\' {
+			return "", false
+		}
+		b.WriteString(command[:i-1] + "%")
+		command = command[i+1:]
+	}
+}
+
+func splitCrontabLine(line string) (spec, command string) {
+	n := len(cronFields)
+	switch {
+	case strings.HasPrefix(line, "@every"):
+		n = 2
+	case strings.HasPrefix(line, "@"):
+		n = 1
+	}
+
+	rest := line
+	for i := 0; i < n; i++ {
+		rest = strings.TrimLeftFunc(rest, unicode.IsSpace)
+		end := strings.IndexFunc(rest, unicode.IsSpace)
+		if end < 0 {
+			return line, ""
+		}
+		rest = rest[end:]
+	}
+	command = strings.TrimSpace(rest)
+	return strings.TrimSpace(line[:len(line)-len(rest)]), command
+}
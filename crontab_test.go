@@ -0,0 +1,89 @@
+package every
+
+import (
+	"context"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestImportCrontab(t *testing.T) {
+	const crontab = `
+# nightly jobs
+SHELL=/bin/sh
+MAILTO = ops@example.com
+@reboot /usr/local/bin/warm-cache
+*/5 * * * * /usr/local/bin/sync a=b
+@daily /usr/local/bin/rotate
+`
+	var commands []string
+	s := NewScheduler()
+	tasks, err := s.ImportCrontab(strings.NewReader(crontab), func(command string) (func(context.Context) error, error) {
+		commands = append(commands, command)
+		return func(context.Context) error { return nil }, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"/usr/local/bin/sync a=b", "/usr/local/bin/rotate"}
+	if strings.Join(commands, "\n") != strings.Join(want, "\n") || len(tasks) != len(want) {
+		t.Errorf("imported %q, want %q", commands, want)
+	}
+}
+
+func TestImportCrontabPercent(t *testing.T) {
+	for _, tc := range []struct {
+		line, command string
+		ok            bool
+	}{
+		{`@daily date +\%F`, "date +%F", true},
+		{`@daily backup \%a \%b`, "backup %a %b", true},
+		{`@daily mail -s hi ops%body`, "", false},
+		{`@daily date +%F`, "", false},
+	} {
+		var got string
+		_, err := NewScheduler().ImportCrontab(strings.NewReader(tc.line), func(command string) (func(context.Context) error, error) {
+			got = command
+			return func(context.Context) error { return nil }, nil
+		})
+		if (err == nil) != tc.ok || got != tc.command {
+			t.Errorf("%s: command %q, error %v; want %q, ok %v", tc.line, got, err, tc.command, tc.ok)
+		}
+	}
+}
+
+func TestImportCrontabFromRegistry(t *testing.T) {
+	ran := false
+	tasks, err := NewScheduler().ImportCrontab(strings.NewReader("@hourly sync"), Registry{"sync": func() { ran = true }}.Lookup)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tasks[0].taskFunc(context.Background()); err != nil || !ran {
+		t.Errorf("registered func ran %v, %v", ran, err)
+	}
+}
+
+func TestShellCommand(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("sh commands")
+	}
+	fail, err := ShellCommand("exit 3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := fail(context.Background()); err == nil {
+		t.Error("command exiting 3 reported success")
+	}
+
+	sleep, _ := ShellCommand("sleep 10")
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if err := sleep(ctx); err == nil {
+		t.Error("killed command reported success")
+	}
+	if d := time.Since(start); d > 5*time.Second {
+		t.Errorf("command ran on for %v after its context was done", d)
+	}
+}
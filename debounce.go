@@ -0,0 +1,92 @@
+package every
+
+import (
+	"context"
+	"time"
+)
+
+// events replaces a task's schedule with fires driven by Trigger calls.
+// Both methods run on the task's loop goroutine.
+type events interface {
+	// trigger returns the fire time after a Trigger at now, given the
+	// currently pending fire time, which is zero if none is pending.
+	trigger(now, next time.Time) time.Time
+	// ran returns the fire time after a run from start to end.
+	ran(start, end time.Time) time.Time
+}
+
+func (t *Task) interval() time.Duration {
+	d, _ := t.schedule.(intervalSchedule)
+	return time.Duration(d)
+}
+
+// trigger asks the run loop to handle a fire request without blocking;
+// requests made while one is already pending are coalesced.
+func (t *Task) trigger() {
+	if t.afterFunc {
+		t.kickAfterFunc()
+		return
+	}
+	select {
+	case t.triggerChan <- struct{}{}:
+	default:
+	}
+}
+
+// Debouncer runs its func once Trigger has not been called for a full
+// interval, however often it was called before that.
+type Debouncer struct {
+	*Task
+}
+
+type debounce struct {
+	task  *Task
+	first time.Time
+}
+
+func NewDebouncer(interval string, fn func(), opts ...Option) (*Debouncer, error) {
+	return NewContextDebouncer(interval, func(context.Context) { fn() }, opts...)
+}
+
+func NewContextDebouncer(interval string, fn func(ctx context.Context), opts ...Option) (*Debouncer, error) {
+	duration, err := parseDuration(interval)
+	if err != nil {
+		return nil, err
+	}
+
+	t := newTask(intervalSchedule(duration), func(ctx context.Context) error {
+		fn(ctx)
+		return nil
+	})
+	t.apply(opts)
+	t.events = &debounce{task: t}
+	return &Debouncer{Task: t}, nil
+}
+
+// WithMaxWait bounds how long a Debouncer may keep postponing its run while
+// triggers keep arriving, measured from the first trigger.
+func WithMaxWait(d time.Duration) Option {
+	return func(t *Task) {
+		t.maxWait = d
+	}
+}
+
+func (d *Debouncer) Trigger() {
+	d.trigger()
+}
+
+func (d *debounce) trigger(now, next time.Time) time.Time {
+	if next.IsZero() {
+		d.first = now
+	}
+	at := now.Add(d.task.interval())
+	if limit := d.first.Add(d.task.maxWait); d.task.maxWait > 0 && at.After(limit) {
+		at = limit
+	}
+	return at
+}
+
+func (d *debounce) ran(time.Time, time.Time) time.Time {
+	d.first = time.Time{}
+	return time.Time{}
+}
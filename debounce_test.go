@@ -0,0 +1,33 @@
+package every
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDebounce(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		maxWait  time.Duration
+		triggers []int // seconds after the first
+		want     []int // fire time after each trigger
+	}{
+		{"single", 0, []int{0}, []int{10}},
+		{"postponed", 0, []int{0, 3, 8}, []int{10, 13, 18}},
+		{"max wait", 15 * time.Second, []int{0, 8, 12}, []int{10, 15, 15}},
+		{"max wait unreached", 30 * time.Second, []int{0, 8, 12}, []int{10, 18, 22}},
+	} {
+		d, err := NewDebouncer("10s", func() {}, WithMaxWait(tc.maxWait))
+		if err != nil {
+			t.Fatal(err)
+		}
+		start := time.Now()
+		var next time.Time
+		for i, s := range tc.triggers {
+			next = d.events.trigger(start.Add(time.Duration(s)*time.Second), next)
+			if want := start.Add(time.Duration(tc.want[i]) * time.Second); !next.Equal(want) {
+				t.Errorf("%s: trigger at %ds fires at %s, want %ds", tc.name, s, next.Sub(start), tc.want[i])
+			}
+		}
+	}
+}
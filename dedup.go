@@ -0,0 +1,37 @@
+package every
+
+import "time"
+
+// WithDedupKey calls key for every value received from the WithTrigger
+// channel and ignores the trigger if one with the same key was accepted
+// within window, so several signals about the same entity cause one run.
+// An empty key is never deduplicated.
+func WithDedupKey(key func() string, window time.Duration) Option {
+	return func(t *Task) {
+		t.dedupKey, t.dedupWindow = key, window
+	}
+}
+
+// duplicate reports whether a trigger at now repeats an accepted one,
+// recording it otherwise.
+func (t *Task) duplicate(now time.Time) bool {
+	key := t.dedupKey()
+	if key == "" {
+		return false
+	}
+	if seen, ok := t.dedupSeen[key]; ok && now.Sub(seen) < t.dedupWindow {
+		t.log.Debug("duplicate trigger ignored", "key", key)
+		return true
+	}
+
+	if t.dedupSeen == nil {
+		t.dedupSeen = make(map[string]time.Time)
+	}
+	for k, seen := range t.dedupSeen {
+		if now.Sub(seen) >= t.dedupWindow {
+			delete(t.dedupSeen, k)
+		}
+	}
+	t.dedupSeen[key] = now
+	return false
+}
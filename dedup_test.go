@@ -0,0 +1,41 @@
+package every
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDedupKey(t *testing.T) {
+	var key string
+	task, err := NewTask("1h", func() {}, WithDedupKey(func() string { return key }, time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	task.log = task.Logger()
+
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	for _, step := range []struct {
+		at   time.Duration
+		key  string
+		want bool
+	}{
+		{0, "order-1", false},
+		{10 * time.Second, "order-1", true},
+		{20 * time.Second, "order-2", false},
+		{30 * time.Second, "", false},
+		{40 * time.Second, "", false},
+		{50 * time.Second, "order-1", true},
+		// The window counts from the accepted trigger, not the ignored ones.
+		{time.Minute, "order-1", false},
+		{70 * time.Second, "order-2", true},
+		{80 * time.Second, "order-2", false},
+	} {
+		key = step.key
+		if got := task.duplicate(start.Add(step.at)); got != step.want {
+			t.Errorf("trigger %q at +%v: duplicate = %v, want %v", step.key, step.at, got, step.want)
+		}
+	}
+	if len(task.dedupSeen) != 2 {
+		t.Errorf("%d keys remembered, want the 2 within the window", len(task.dedupSeen))
+	}
+}
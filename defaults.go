@@ -0,0 +1,47 @@
+package every
+
+// SetDefaults sets options for every task added afterwards, applied before
+// the task's own so that those win, which saves repeating a logger,
+// timeout or retry policy on each registration. Options that affect
+// parsing, such as WithSeconds, or the task's lifetime, such as
+// WithContext, have no effect as defaults. Tasks must be added before
+// they are started.
+func (s *Scheduler) SetDefaults(opts ...Option) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.defaults = opts
+}
+
+func (s *Scheduler) defaultOptions() []Option {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.defaults
+}
+
+// inherit rebuilds t's options as defaults followed by its own. Fields its
+// constructor set directly, such as a Debouncer's events or an imported
+// task's name, are kept.
+func (t *Task) inherit(defaults []Option) {
+	d := newTask(t.schedule, t.taskFunc)
+	for _, opt := range defaults {
+		opt(d)
+	}
+	for _, opt := range t.opts {
+		opt(d)
+	}
+	if d.unwatch != nil {
+		d.unwatch()
+	}
+	d.cancel()
+
+	events := t.events
+	t.settings = d.settings
+	t.events = events
+	t.tags = d.tags
+	t.logger = d.logger
+	if t.name == "" {
+		t.name = d.name
+	}
+}
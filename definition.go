@@ -0,0 +1,130 @@
+package every
+
+import "fmt"
+
+// TaskDefinition is the serialisable form of a task: its schedule and the
+// options that are plain values, with the task func referred to by name.
+// It encodes with encoding/json and encoding/gob as is. Options that take
+// funcs or interfaces, such as calendars and hooks, are not part of a
+// definition and can be passed to Build alongside it.
+type TaskDefinition struct {
+	Name         string        `json:"name,omitempty"`
+	Func         string        `json:"func"`
+	Schedule     string        `json:"schedule"`
+	Tags         []string      `json:"tags,omitempty"`
+	Seconds      bool          `json:"seconds,omitempty"`
+	Coordinates  *[2]float64   `json:"coordinates,omitempty"`
+	Misfire      MisfirePolicy `json:"misfire,omitempty"`
+	BusinessDays bool          `json:"business_days,omitempty"`
+	Roll         RollPolicy    `json:"roll,omitempty"`
+	Cooldown     string        `json:"cooldown,omitempty"`
+	Weight       float64       `json:"weight,omitempty"`
+}
+
+// Build creates a task from d, looking its func up in r. Extra options are
+// applied after the ones the definition describes. If the func is missing
+// or nil, the task's MissingFuncPolicy decides whether Build fails.
+func (d TaskDefinition) Build(r Registry, opts ...Option) (*Task, error) {
+	return d.build(r, nil, opts)
+}
+
+// build is Build with a Scheduler's defaults applied first, so that the
+// definition's own options win over them.
+func (d TaskDefinition) build(r Registry, defaults, opts []Option) (*Task, error) {
+	fn, missing := r.Lookup(d.Func)
+	if missing == nil && fn == nil {
+		missing = fmt.Errorf("nil function: %s", d.Func)
+	}
+
+	var defOpts []Option
+	if d.Name != "" {
+		defOpts = append(defOpts, WithName(d.Name))
+	}
+	if len(d.Tags) > 0 {
+		defOpts = append(defOpts, WithTags(d.Tags...))
+	}
+	if d.Misfire != MisfireFireNow {
+		defOpts = append(defOpts, WithMisfirePolicy(d.Misfire))
+	}
+	if d.Roll != RollSkip {
+		defOpts = append(defOpts, WithRollPolicy(d.Roll))
+	}
+	if d.Weight != 0 {
+		defOpts = append(defOpts, WithWeight(d.Weight))
+	}
+	if d.Seconds {
+		defOpts = append(defOpts, WithSeconds())
+	}
+	if d.Coordinates != nil {
+		defOpts = append(defOpts, WithCoordinates(d.Coordinates[0], d.Coordinates[1]))
+	}
+	if d.BusinessDays {
+		defOpts = append(defOpts, WithBusinessDays())
+	}
+	if d.Cooldown != "" {
+		cooldown, err := parseDuration(d.Cooldown)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cooldown: %s", d.Cooldown)
+		}
+		defOpts = append(defOpts, WithCooldown(cooldown))
+	}
+
+	all := append(append(append([]Option(nil), defaults...), defOpts...), opts...)
+	t, err := NewErrorTask(d.Schedule, fn, all...)
+	if err != nil {
+		return nil, err
+	}
+	// The Scheduler applies its defaults again when the task is added, so
+	// they are left out of the options the task remembers.
+	t.opts = t.opts[len(defaults):]
+	if missing != nil {
+		if err := t.missing(missing); err != nil {
+			t.cancel()
+			return nil, err
+		}
+	}
+	t.funcName = d.Func
+	return t, nil
+}
+
+// Definition describes t as a TaskDefinition. Func is only set for tasks
+// built from a definition.
+func (t *Task) Definition() TaskDefinition {
+	d := TaskDefinition{
+		Name:         t.name,
+		Func:         t.funcName,
+		Schedule:     t.Schedule(),
+		Tags:         t.Tags(),
+		Seconds:      t.parser.seconds,
+		Misfire:      t.misfire,
+		BusinessDays: t.businessDays,
+		Roll:         t.roll,
+		Weight:       t.poolWeight,
+	}
+	if c := t.parser.coords; c != nil {
+		d.Coordinates = &[2]float64{c.lat, c.lon}
+	}
+	if t.cooldown > 0 {
+		d.Cooldown = formatDuration(t.cooldown)
+	}
+	return d
+}
+
+// Load builds and adds a task for each definition. Nothing is added unless
+// every definition builds.
+func (s *Scheduler) Load(defs []TaskDefinition, r Registry) ([]*Task, error) {
+	tasks := make([]*Task, 0, len(defs))
+	defaults := s.defaultOptions()
+	for i, d := range defs {
+		t, err := d.build(r, defaults, nil)
+		if err != nil {
+			return nil, fmt.Errorf("definition %d: %w", i, err)
+		}
+		tasks = append(tasks, t)
+	}
+
+	for _, t := range tasks {
+		s.Add(t)
+	}
+	return tasks, nil
+}
@@ -0,0 +1,107 @@
+package every
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestDefinitionOverridesDefaults(t *testing.T) {
+	s := NewScheduler()
+	s.SetDefaults(WithMisfirePolicy(MisfireDoNothing), WithCooldown(time.Minute))
+	defs := []TaskDefinition{{Name: "sync", Func: "sync", Schedule: "1h", Misfire: MisfireCatchUp}}
+	r := Registry{"sync": func() {}}
+
+	loaded, err := s.Load(defs, r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s2 := NewScheduler()
+	s2.SetDefaults(WithMisfirePolicy(MisfireDoNothing), WithCooldown(time.Minute))
+	applied, err := s2.Apply(defs, r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, task := range []*Task{loaded[0], applied.Added[0]} {
+		if task.misfire != MisfireCatchUp {
+			t.Errorf("misfire = %v, want %v", task.misfire, MisfireCatchUp)
+		}
+		if task.cooldown != time.Minute {
+			t.Errorf("cooldown = %v, want the default %v", task.cooldown, time.Minute)
+		}
+	}
+}
+
+func TestApplyWithDefaultsIsIdempotent(t *testing.T) {
+	s := NewScheduler()
+	s.SetDefaults(WithTags("batch"), WithCooldown(time.Minute))
+	defs := []TaskDefinition{
+		{Name: "sync", Func: "sync", Schedule: "1h", Tags: []string{"io"}},
+		{Name: "report", Func: "sync", Schedule: "@daily"},
+	}
+	r := Registry{"sync": func() {}}
+
+	if _, err := s.Apply(defs, r); err != nil {
+		t.Fatal(err)
+	}
+	res, err := s.Apply(defs, r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Added)+len(res.Rescheduled)+len(res.Replaced)+len(res.Removed) > 0 {
+		t.Errorf("second Apply changed tasks: %+v", res)
+	}
+	for _, task := range s.Snapshot() {
+		if task.Name == "sync" && !slices.Equal(task.Tags, []string{"batch", "io"}) {
+			t.Errorf("tags = %q, want the default once then the definition's", task.Tags)
+		}
+	}
+}
+
+func TestDefinitionLeavesUnsetFieldsToDefaults(t *testing.T) {
+	s := NewScheduler()
+	s.SetDefaults(WithName("default"), WithMisfirePolicy(MisfireDoNothing), WithRollPolicy(RollForward), WithWeight(2))
+	tasks, err := s.Load([]TaskDefinition{{Func: "sync", Schedule: "1h"}}, Registry{"sync": func() {}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	task := tasks[0]
+	for _, tc := range []struct {
+		field     string
+		got, want any
+	}{
+		{"name", task.name, "default"},
+		{"misfire", task.misfire, MisfireDoNothing},
+		{"roll", task.roll, RollForward},
+		{"weight", task.poolWeight, 2.0},
+	} {
+		if tc.got != tc.want {
+			t.Errorf("%s = %v with the field unset, want the default %v", tc.field, tc.got, tc.want)
+		}
+	}
+}
+
+func TestApplyReplaceKeepsNamespace(t *testing.T) {
+	s := NewScheduler()
+	old, err := NewTask("1h", func() {}, WithName("sync"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Namespace("team").Add(old); err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := s.Apply([]TaskDefinition{{Name: "sync", Func: "sync", Schedule: "1h", Tags: []string{"io"}}}, Registry{"sync": func() {}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Replaced) != 1 {
+		t.Fatalf("Apply() = %+v, want sync replaced", res)
+	}
+	tasks := s.Namespace("team").Tasks()
+	if len(tasks) != 1 || tasks[0] == old || tasks[0].Namespace() != "team" {
+		t.Errorf("namespace tasks after replace: %v", tasks)
+	}
+}
@@ -0,0 +1,155 @@
+package every
+
+import (
+	"fmt"
+	"time"
+)
+
+// Delivery chooses what a task does about a fire that may or may not have
+// run, because the process running it crashed or lost its lease mid-run.
+type Delivery int
+
+const (
+	// DeliveryBestEffort keeps no record of fires: one in doubt is
+	// forgotten, and two Schedulers sharing nothing may both run a fire.
+	DeliveryBestEffort Delivery = iota
+	// AtMostOnce never runs a fire twice. Before running a fire, the task
+	// records in its JobStore that it has started it, and it skips any
+	// fire the store shows as started or done, here or by another process
+	// sharing the store, counting a SkipDelivered skip. A fire in doubt is
+	// therefore dropped, as is a fire whose start could not be recorded.
+	AtMostOnce
+	// AtLeastOnce never loses a fire. The task records fires as it does
+	// for AtMostOnce, but reruns one in doubt: when it starts and finds a
+	// fire recorded as started but not done, it runs that fire straight
+	// away, whatever the misfire policy; and it runs fires another process
+	// started but never finished. Fires recorded as done are skipped. If
+	// the store cannot be read, the fire runs.
+	AtLeastOnce
+)
+
+func (d Delivery) String() string {
+	switch d {
+	case DeliveryBestEffort:
+		return "best-effort"
+	case AtMostOnce:
+		return "at-most-once"
+	case AtLeastOnce:
+		return "at-least-once"
+	}
+	return "unknown"
+}
+
+func (d Delivery) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// WithDelivery sets the task's delivery guarantee across crashes and
+// failovers. Guarantees other than DeliveryBestEffort need a name and a
+// WithJobStore store, shared by every process that may run the task, in
+// which fires are recorded by their scheduled time; the store must offer
+// read-after-write consistency between those processes. The record is not
+// a lock: processes that could fire at the same moment must also be kept
+// apart, for instance with Standby, or both may start the same fire. A run
+// that fails still counts as done: failures are for WithRetry to handle.
+func WithDelivery(d Delivery) Option {
+	return func(t *Task) {
+		t.delivery = d
+	}
+}
+
+// deliveryKey is the JobStore key fires are recorded under, next to the
+// task's StateBag.
+func (t *Task) deliveryKey() string {
+	return t.name + "#delivery"
+}
+
+// delivery is a task's record of its fires in its JobStore.
+type delivery struct {
+	started, done time.Time
+}
+
+func (t *Task) loadDelivery() (delivery, error) {
+	values, err := t.store.Load(t.deliveryKey())
+	if err != nil {
+		return delivery{}, fmt.Errorf("loading delivery record: %w", err)
+	}
+	var d delivery
+	for key, at := range map[string]*time.Time{"started": &d.started, "done": &d.done} {
+		if v, ok := values[key]; ok {
+			if err := at.UnmarshalText(v); err != nil {
+				return delivery{}, fmt.Errorf("loading delivery record: %w", err)
+			}
+		}
+	}
+	return d, nil
+}
+
+func (t *Task) saveDelivery(d delivery) error {
+	started, _ := d.started.MarshalText()
+	done, _ := d.done.MarshalText()
+	if err := t.store.Save(t.deliveryKey(), map[string][]byte{"started": started, "done": done}); err != nil {
+		return fmt.Errorf("saving delivery record: %w", err)
+	}
+	return nil
+}
+
+// recoverDelivery is called as the task starts. It returns the fire left
+// in doubt by an earlier process, if there is one to rerun.
+func (t *Task) recoverDelivery() (time.Time, bool) {
+	if !t.guaranteed() {
+		return time.Time{}, false
+	}
+	d, err := t.loadDelivery()
+	if err != nil {
+		t.log.Warn("delivery record unreadable", "error", err, "delivery", t.delivery)
+		return time.Time{}, false
+	}
+	if !d.started.After(d.done) {
+		return time.Time{}, false
+	}
+	if t.delivery == AtLeastOnce {
+		t.log.Warn("rerunning fire in doubt", "scheduled", d.started)
+		return d.started, true
+	}
+	t.log.Warn("dropping fire in doubt", "scheduled", d.started)
+	d.done = d.started
+	if err := t.saveDelivery(d); err != nil {
+		t.log.Warn("delivery record not saved", "error", err)
+	}
+	return time.Time{}, false
+}
+
+// claim records that the fire scheduled for at is starting and reports
+// whether it should run.
+func (t *Task) claim(at time.Time) bool {
+	d, err := t.loadDelivery()
+	switch {
+	case err != nil:
+		t.log.Warn("delivery record unreadable", "error", err, "delivery", t.delivery)
+		return t.delivery == AtLeastOnce
+	case !d.done.Before(at):
+		return false
+	case !d.started.Before(at) && t.delivery == AtMostOnce:
+		return false
+	}
+	d.started = at
+	if err := t.saveDelivery(d); err != nil {
+		t.log.Warn("delivery record not saved", "error", err, "delivery", t.delivery)
+		return t.delivery == AtLeastOnce
+	}
+	return true
+}
+
+// delivered records that the fire scheduled for at has run.
+func (t *Task) delivered(at time.Time) {
+	d := delivery{started: at, done: at}
+	if err := t.saveDelivery(d); err != nil {
+		t.log.Warn("delivery record not saved", "error", err, "delivery", t.delivery)
+	}
+}
+
+// guaranteed reports whether the task keeps a delivery record.
+func (t *Task) guaranteed() bool {
+	return t.delivery != DeliveryBestEffort && t.store != nil
+}
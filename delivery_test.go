@@ -0,0 +1,100 @@
+package every
+
+import (
+	"testing"
+	"time"
+)
+
+// delivering returns a task with a delivery guarantee whose record in a
+// fresh store starts as d, set up to be fired directly.
+func delivering(t *testing.T, guarantee Delivery, d delivery) *Task {
+	t.Helper()
+	store, err := NewFileStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	seed := &Task{name: "job", settings: settings{store: store}}
+	if err := seed.saveDelivery(d); err != nil {
+		t.Fatal(err)
+	}
+	return firing(t, WithName("job"), WithJobStore(store), WithDelivery(guarantee))
+}
+
+func record(t *testing.T, task *Task) delivery {
+	t.Helper()
+	d, err := task.loadDelivery()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return d
+}
+
+func TestDeliveryRecordsFires(t *testing.T) {
+	for _, guarantee := range []Delivery{AtMostOnce, AtLeastOnce} {
+		task := delivering(t, guarantee, delivery{})
+		at := time.Now()
+		task.due = at
+		task.fire(at)
+		if task.runs != 1 {
+			t.Fatalf("%v: %d runs, want 1", guarantee, task.runs)
+		}
+		if d := record(t, task); !d.started.Equal(at) || !d.done.Equal(at) {
+			t.Errorf("%v: record %+v, want started and done at %v", guarantee, d, at)
+		}
+	}
+}
+
+func TestDeliverySkipsFiresDoneElsewhere(t *testing.T) {
+	at := time.Now().Truncate(time.Second)
+	for _, guarantee := range []Delivery{AtMostOnce, AtLeastOnce} {
+		task := delivering(t, guarantee, delivery{started: at, done: at})
+		var skipped []SkipReason
+		task.onSkip = func(r SkipReason) { skipped = append(skipped, r) }
+		task.due = at
+		task.fire(at)
+		if task.runs != 0 {
+			t.Errorf("%v: fire done by another process ran", guarantee)
+		}
+		if len(skipped) != 1 || skipped[0] != SkipDelivered {
+			t.Errorf("%v: skipped %v, want [%v]", guarantee, skipped, SkipDelivered)
+		}
+	}
+}
+
+func TestDeliveryFireInDoubt(t *testing.T) {
+	at := time.Now().Add(-time.Second).Truncate(time.Second)
+	in := delivery{started: at, done: at.Add(-time.Hour)}
+
+	task := delivering(t, AtMostOnce, in)
+	if d := record(t, task); !d.done.Equal(at) {
+		t.Errorf("AtMostOnce: fire in doubt not marked done on start: %+v", d)
+	}
+	task.due = at
+	task.fire(time.Now())
+	if task.runs != 0 {
+		t.Error("AtMostOnce: fire in doubt ran")
+	}
+
+	task = delivering(t, AtLeastOnce, in)
+	if !task.due.Equal(at) {
+		t.Fatalf("AtLeastOnce: next fire %v, want the fire in doubt at %v", task.due, at)
+	}
+	task.fire(time.Now())
+	if task.runs != 1 || !task.lastScheduled.Equal(at) {
+		t.Errorf("AtLeastOnce: fire in doubt not rerun: %d runs, last scheduled %v", task.runs, task.lastScheduled)
+	}
+	if d := record(t, task); !d.done.Equal(at) {
+		t.Errorf("AtLeastOnce: rerun fire not recorded as done: %+v", d)
+	}
+}
+
+func TestDeliveryDroppedFireIsNotClaimed(t *testing.T) {
+	task := delivering(t, AtLeastOnce, delivery{})
+	task.draining.Store(true)
+	at := time.Now()
+	task.due = at
+	task.fire(at)
+	if d := record(t, task); !d.started.IsZero() {
+		t.Errorf("fire dropped while draining was recorded as started at %v", d.started)
+	}
+}
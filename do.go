@@ -0,0 +1,24 @@
+package every
+
+// Do creates a task running fn every interval and starts it, for quick
+// one-liners. Keep the returned task to stop or reconfigure it later.
+func Do(interval string, fn func(), opts ...Option) (*Task, error) {
+	t, err := NewTask(interval, fn, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if err := t.TryStart(); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// MustDo is like Do but panics if the task cannot be created, for wiring
+// up tasks with fixed schedules in init or package variables.
+func MustDo(interval string, fn func(), opts ...Option) *Task {
+	t, err := Do(interval, fn, opts...)
+	if err != nil {
+		panic("every: " + err.Error())
+	}
+	return t
+}
@@ -0,0 +1,57 @@
+package every
+
+import (
+	"context"
+	"time"
+)
+
+// WaitForFirstRun blocks until the task has completed a run successfully,
+// so startup code can make sure an initial fetch or cache fill happened
+// before going on. It returns at once if that has already happened,
+// ErrTaskStopped if the task's run loop exits first, or ctx's error.
+func (t *Task) WaitForFirstRun(ctx context.Context) error {
+	select {
+	case <-t.succeeded:
+		return nil
+	default:
+	}
+
+	select {
+	case <-t.succeeded:
+		return nil
+	case <-t.done:
+		select {
+		case <-t.succeeded:
+			return nil
+		default:
+			return ErrTaskStopped
+		}
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Done returns a channel that is closed once the task will not run again:
+// after Stop returns, when a Supervisor gives up on it, or when the run of
+// a one-shot task such as one from RunAt is over and nothing else is due.
+// It is not closed when other runs finish.
+func (t *Task) Done() <-chan struct{} {
+	return t.ended
+}
+
+func (t *Task) exited() {
+	t.asyncRuns.Wait()
+	t.doneOnce.Do(func() { close(t.done) })
+	t.endOnce.Do(func() { close(t.ended) })
+}
+
+// spent reports whether t is a one-shot with no fire left after next.
+func (t *Task) spent(next time.Time) bool {
+	_, once := t.schedule.(onceSchedule)
+	return once && next.IsZero()
+}
+
+// retire closes Done once a one-shot task has had its run.
+func (t *Task) retire() {
+	t.endOnce.Do(func() { close(t.ended) })
+}
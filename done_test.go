@@ -0,0 +1,37 @@
+package every
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDoneAfterOneShot(t *testing.T) {
+	for _, mode := range []struct {
+		name string
+		opts []Option
+	}{
+		{"timer", nil},
+		{"afterfunc", []Option{WithAfterFunc()}},
+		{"async", []Option{WithAsyncRuns()}},
+	} {
+		t.Run(mode.name, func(t *testing.T) {
+			ran := make(chan struct{})
+			task, err := RunAt(time.Now().Add(20*time.Millisecond), func() { close(ran) }, mode.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer task.Stop()
+
+			select {
+			case <-task.Done():
+				t.Fatal("Done closed before the run")
+			case <-ran:
+			}
+			select {
+			case <-task.Done():
+			case <-time.After(time.Second):
+				t.Fatal("Done not closed after the one-shot ran")
+			}
+		})
+	}
+}
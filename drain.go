@@ -0,0 +1,48 @@
+package every
+
+import (
+	"context"
+	"time"
+)
+
+// drainPoll is how often Drain checks for runs still in progress.
+const drainPoll = 10 * time.Millisecond
+
+// Drain winds the Scheduler down for a rolling deploy: no new runs start,
+// runs in progress finish with their contexts intact, and then every task
+// is stopped, running final runs and OnStop hooks, and any Standby lease
+// is released. If ctx ends before the runs finish, the tasks are stopped
+// anyway, cancelling those runs, and ctx's error is returned.
+func (s *Scheduler) Drain(ctx context.Context) error {
+	tasks := s.Tasks()
+	for _, t := range tasks {
+		t.draining.Store(true)
+	}
+
+	ticker := time.NewTicker(drainPoll)
+	defer ticker.Stop()
+	var err error
+	for _, t := range tasks {
+		for err == nil && t.inflight.Load() > 0 {
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				err = ctx.Err()
+			}
+		}
+	}
+	s.StopAll()
+	return err
+}
+
+// admit counts a run as in progress unless the task is draining, in which
+// case the fire is dropped and the task waits to be stopped.
+func (t *Task) admit() bool {
+	t.inflight.Add(1)
+	if t.draining.Load() {
+		t.inflight.Add(-1)
+		t.log.Debug("fire dropped while draining")
+		return false
+	}
+	return true
+}
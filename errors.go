@@ -0,0 +1,119 @@
+package every
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrInvalidUnit is wrapped by errors for an interval with a missing or
+	// unknown unit, such as "5" or "5w".
+	ErrInvalidUnit = errors.New("invalid unit")
+	// ErrInvalidSchedule is wrapped by errors for a schedule that is not
+	// one at all, such as an unknown descriptor or a cron expression with
+	// the wrong number of fields, or that can never fire.
+	ErrInvalidSchedule = errors.New("invalid schedule")
+	// ErrInvalidValue is wrapped by errors for a number or time of day in a
+	// schedule that is malformed or out of range.
+	ErrInvalidValue = errors.New("invalid value")
+	// ErrTaskStopped is returned when a stopped task is started, updated or
+	// run.
+	ErrTaskStopped = errors.New("task stopped")
+	// ErrAlreadyRunning is returned by TryStart for a task already started.
+	ErrAlreadyRunning = errors.New("task already running")
+	// ErrNotStarted is returned by RunNow for a task not yet started.
+	ErrNotStarted = errors.New("task not started")
+	// ErrTimeout is wrapped by the error recorded for a run that outlives
+	// its WithTimeout limit.
+	ErrTimeout = errors.New("run timed out")
+	// ErrPreempted is the error recorded for a run cancelled to make room
+	// for a preempting task, and the cause of its context.
+	ErrPreempted = errors.New("run preempted")
+	// ErrNoHistory is returned by Rollback when there is no earlier
+	// schedule to go back to.
+	ErrNoHistory = errors.New("no previous schedule")
+	// ErrQuotaExceeded is returned when adding a task would take a
+	// Namespace over its task quota.
+	ErrQuotaExceeded = errors.New("namespace quota exceeded")
+	// ErrNoFunc is the reason a task without a func is disabled.
+	ErrNoFunc = errors.New("task has no func")
+	// ErrNoWatchdog is returned by NewWatchdogTask and EnableWatchdog when
+	// the process was not started with the systemd watchdog enabled.
+	ErrNoWatchdog = errors.New("systemd watchdog not enabled")
+)
+
+// ParseError reports an interval that could not be parsed. Token is the
+// offending part of Input and Index its byte offset; a missing unit is an
+// empty Token at the end of Input. Suggestion, when set, is a corrected
+// interval the input was likely meant to be.
+type ParseError struct {
+	Input      string
+	Token      string
+	Index      int
+	Suggestion string
+	// Err is ErrInvalidUnit or ErrInvalidValue.
+	Err error
+}
+
+func (e *ParseError) Error() string {
+	msg := fmt.Sprintf("%v in duration: %s", e.Err, e.Input)
+	switch {
+	case e.Token != "":
+		msg += fmt.Sprintf(" (%q at %d)", e.Token, e.Index)
+	case e.Input != "":
+		msg += fmt.Sprintf(" (missing unit at %d)", e.Index)
+	}
+	if e.Suggestion != "" {
+		msg += fmt.Sprintf(", did you mean %q?", e.Suggestion)
+	}
+	return msg
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// unitAliases maps unit spellings people commonly try to the unit they
+// mean, with a multiplier for units the grammar lacks.
+var unitAliases = map[string]struct {
+	unit string
+	mult int
+}{
+	"sec": {"s", 1}, "secs": {"s", 1}, "second": {"s", 1}, "seconds": {"s", 1},
+	"min": {"m", 1}, "mins": {"m", 1}, "minute": {"m", 1}, "minutes": {"m", 1},
+	"hr": {"h", 1}, "hrs": {"h", 1}, "hour": {"h", 1}, "hours": {"h", 1},
+	"day": {"d", 1}, "days": {"d", 1},
+	"w": {"d", 7}, "wk": {"d", 7}, "week": {"d", 7}, "weeks": {"d", 7},
+}
+
+// suggestOrder proposes the canonical form of an interval with repeated or
+// out of order units.
+func suggestOrder(in string) string {
+	d, err := parseDuration(in)
+	if err != nil {
+		return ""
+	}
+	return formatDuration(d)
+}
+
+// suggestUnit proposes a fix for the bad unit in[j:k] following the value
+// in[i:j], or returns "" if it has none.
+func suggestUnit(in string, i, j, k int) string {
+	unit := strings.ToLower(in[j:k])
+	value := in[i:j]
+	switch alias, ok := unitAliases[unit]; {
+	case ok && alias.mult == 1:
+		unit = alias.unit
+	case ok:
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return ""
+		}
+		value, unit = strconv.Itoa(n*alias.mult), alias.unit
+	case !strings.Contains("smhd", unit) || len(unit) != 1:
+		return ""
+	}
+	return in[:i] + value + unit + in[k:]
+}
@@ -0,0 +1,60 @@
+package every
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseErrorsWrapSentinels(t *testing.T) {
+	for _, tc := range []struct {
+		spec string
+		want error
+	}{
+		{"@fortnightly", ErrInvalidSchedule},
+		{"* * * *", ErrInvalidSchedule},
+		{"0 0 31 2 *", ErrInvalidSchedule},
+		{"61 * * * *", ErrInvalidValue},
+		{"daily@25:00", ErrInvalidValue},
+		{"5w", ErrInvalidUnit},
+	} {
+		if _, err := ParseSchedule(tc.spec); !errors.Is(err, tc.want) {
+			t.Errorf("ParseSchedule(%q) = %v, want %v", tc.spec, err, tc.want)
+		}
+	}
+	if _, err := ParseSchedule("* * * * *", WithSeconds()); !errors.Is(err, ErrInvalidSchedule) {
+		t.Errorf("five fields with WithSeconds: %v, want ErrInvalidSchedule", err)
+	}
+
+	var m MisfirePolicy
+	if err := m.UnmarshalText([]byte("sometimes")); !errors.Is(err, ErrInvalidValue) {
+		t.Errorf("MisfirePolicy.UnmarshalText() = %v, want ErrInvalidValue", err)
+	}
+	var r RollPolicy
+	if err := r.UnmarshalText([]byte("sideways")); !errors.Is(err, ErrInvalidValue) {
+		t.Errorf("RollPolicy.UnmarshalText() = %v, want ErrInvalidValue", err)
+	}
+}
+
+func TestNoWatchdog(t *testing.T) {
+	t.Setenv("NOTIFY_SOCKET", "")
+	if _, err := NewWatchdogTask(); !errors.Is(err, ErrNoWatchdog) {
+		t.Errorf("NewWatchdogTask() = %v, want ErrNoWatchdog", err)
+	}
+}
+
+func TestTryStart(t *testing.T) {
+	task, err := NewTask("1h", func() {})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := task.TryStart(); err != nil {
+		t.Fatal(err)
+	}
+	if err := task.TryStart(); !errors.Is(err, ErrAlreadyRunning) {
+		t.Errorf("second TryStart() = %v, want ErrAlreadyRunning", err)
+	}
+	task.Stop()
+	if err := task.TryStart(); !errors.Is(err, ErrTaskStopped) {
+		t.Errorf("TryStart() after Stop = %v, want ErrTaskStopped", err)
+	}
+}
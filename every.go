@@ -1,85 +1,504 @@
 package every
 
 import (
-	"fmt"
+	"context"
+	"log/slog"
+	"math"
 	"strconv"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
+// settings holds the options a task is configured with, which Clone
+// copies wholesale.
+type settings struct {
+	misfire       MisfirePolicy
+	calendar      Calendar
+	businessDays  bool
+	roll          RollPolicy
+	splay         time.Duration
+	ramp          []Stage
+	warmup        func() error
+	cooldown      time.Duration
+	maxWait       time.Duration
+	events        events
+	onSkip        func(SkipReason)
+	pool          *pool
+	poolWeight    float64
+	coalesce      time.Duration
+	afterFunc     bool
+	notifiers     []notifier
+	audit         *AuditLog
+	supervisor    *Supervisor
+	heartbeat     func()
+	timeout       time.Duration
+	jitter        Jitter
+	retries       int
+	backoff       Backoff
+	warmupDelay   Backoff
+	final         bool
+	onStop        func(ctx context.Context)
+	condition     func() bool
+	extTrigger    <-chan struct{}
+	extReset      bool
+	panicLimit    int
+	panicWindow   time.Duration
+	breakerLimit  int
+	breakerOpen   time.Duration
+	profileLabels bool
+	store         JobStore
+	priority      int
+	preempting    bool
+	shedLoad      func() float64
+	shedAbove     float64
+	dedupKey      func() string
+	dedupWindow   time.Duration
+	queueDepth    int
+	queueFull     OverflowPolicy
+	rebase        RebasePolicy
+	fenceNames    []string
+	fenceSkip     bool
+	traceSize     int
+	resources     Resources
+	historySink   HistorySink
+	delivery      Delivery
+	phase, phases int
+	async         bool
+	missingFunc   MissingFuncPolicy
+}
+
 type Task struct {
-	duration   time.Duration
-	taskFunc   func()
-	timer      *time.Timer
-	stopChan   chan struct{}
-	updateChan chan time.Duration
-	wg         sync.WaitGroup
+	id       TaskID
+	name     string
+	tags     []string
+	taskFunc func(ctx context.Context) error
+	funcName string
+	spec     string
+	parser   parser
+	logger   *slog.Logger
+	ns       *Namespace
+	fences   []*fence
+	opts     []Option
+
+	settings
+
+	// The run loop is the only writer of schedule; mu serialises that
+	// with readers elsewhere. Everything else it publishes is atomic so
+	// the fire path never waits on a reader.
+	mu       sync.Mutex
+	schedule schedule
+	state    atomic.Int32
+	nextRun  atomicTime
+	lastRun  atomicTime
+	stats    counters
+	progress atomic.Pointer[Progress]
+	subs     subscribers
+	inflight atomic.Int32
+	trace    trace
+	standby  atomic.Pointer[standby]
+	draining atomic.Bool
+
+	// versionMu serialises schedule changes so history stays in step
+	// with version.
+	versionMu sync.Mutex
+	version   uint64
+	history   []schedule
+	built     string
+
+	// Owned by the run loop goroutine, or by whoever holds loopMu when the
+	// task runs on time.AfterFunc.
+	timer         *time.Timer
+	due           time.Time
+	log           *slog.Logger
+	debug         bool
+	started       time.Time
+	quietUntil    time.Time
+	failing       bool
+	restarts      []time.Time
+	lastJitter    time.Duration
+	jitterShift   time.Duration
+	finalDone     bool
+	panics        []time.Time
+	failStreak    int
+	openUntil     time.Time
+	disabling     error
+	disabled      bool
+	bag           *StateBag
+	dedupSeen     map[string]time.Time
+	catchUp       bool
+	queue         []time.Time
+	queuedTo      time.Time
+	dequeued      bool
+	replaying     bool
+	armed         time.Time
+	armedWall     time.Time
+	runs          uint64
+	fires         uint64
+	lastRunFire   uint64
+	lastScheduled time.Time
+	resume        time.Time
+	current       run
+	runCtx        context.Context
+
+	ctx         context.Context
+	cancel      context.CancelFunc
+	launched    atomic.Bool
+	stopping    atomic.Bool
+	stopOnce    sync.Once
+	hookOnce    sync.Once
+	unwatch     func() bool
+	stopChan    chan struct{}
+	updateChan  chan schedule
+	updated     chan struct{}
+	enableChan  chan struct{}
+	runChan     chan struct{}
+	triggerChan chan struct{}
+	succeeded   chan struct{}
+	succeedOnce sync.Once
+	done        chan struct{}
+	doneOnce    sync.Once
+	ended       chan struct{}
+	endOnce     sync.Once
+	wg          sync.WaitGroup
+	asyncRuns   sync.WaitGroup
+	outbox      outbox
+
+	loopMu      sync.Mutex
+	looping     bool
+	kick        atomic.Pointer[time.Timer]
+	kickPending atomic.Bool
+	paused      atomic.Bool
+}
+
+func NewTask(interval string, task func(), opts ...Option) (*Task, error) {
+	if task == nil {
+		return NewErrorTask(interval, nil, opts...)
+	}
+	return NewContextTask(interval, func(context.Context) { task() }, opts...)
 }
 
-func NewTask(interval string, task func()) (*Task, error) {
-	duration, err := parseDuration(interval)
+// NewContextTask is like NewTask for funcs that take a context. The context
+// is cancelled when the task is stopped and carries a Reporter and RunInfo
+// for the run. It is reused between runs, so it must not be kept after the
+// func returns.
+func NewContextTask(interval string, task func(ctx context.Context), opts ...Option) (*Task, error) {
+	if task == nil {
+		return NewErrorTask(interval, nil, opts...)
+	}
+	return NewErrorTask(interval, func(ctx context.Context) error {
+		task(ctx)
+		return nil
+	}, opts...)
+}
+
+// NewErrorTask is like NewContextTask for funcs that can fail. Errors are
+// logged, counted in Stats and applied to policies such as WithCooldown.
+func NewErrorTask(interval string, task func(ctx context.Context) error, opts ...Option) (*Task, error) {
+	t := newTask(nil, task)
+	t.apply(opts)
+	if t.spec != "" {
+		interval = t.spec
+	}
+
+	s, err := t.parser.parse(interval)
 	if err != nil {
 		return nil, err
 	}
+	t.schedule = s
+	return t, nil
+}
 
-	return &Task{
-		duration:   duration,
-		taskFunc:   task,
-		stopChan:   make(chan struct{}),
-		updateChan: make(chan time.Duration),
-	}, nil
+// apply applies opts to t, remembering them so a Scheduler's defaults can
+// be slotted in underneath later.
+func (t *Task) apply(opts []Option) {
+	t.opts = append(t.opts, opts...)
+	for _, opt := range opts {
+		opt(t)
+	}
+}
+
+func newTask(s schedule, task func(ctx context.Context) error) *Task {
+	ctx, cancel := context.WithCancel(context.Background())
+	t := &Task{
+		schedule:    s,
+		taskFunc:    task,
+		ctx:         ctx,
+		cancel:      cancel,
+		stopChan:    make(chan struct{}),
+		updateChan:  make(chan schedule),
+		updated:     make(chan struct{}),
+		enableChan:  make(chan struct{}),
+		runChan:     make(chan struct{}),
+		triggerChan: make(chan struct{}, 1),
+		succeeded:   make(chan struct{}),
+		done:        make(chan struct{}),
+		ended:       make(chan struct{}),
+	}
+	// Runs never overlap, so one run context is reused for all of them to
+	// keep the steady-state loop free of allocations.
+	t.current.task = t
+	t.runCtx = context.WithValue(ctx, taskKey{}, &t.current)
+	return t
 }
 
 func parseDuration(interval string) (time.Duration, error) {
+	return parser{}.duration(interval)
+}
+
+// duration parses a compound interval such as "1h30m". Strict parsers
+// insist on each unit appearing at most once, largest first, with no
+// surrounding whitespace; lenient ones trim whitespace and add up the
+// components in any order.
+func (p parser) duration(interval string) (time.Duration, error) {
 	unitMap := map[byte]time.Duration{'s': time.Second, 'm': time.Minute, 'h': time.Hour, 'd': 24 * time.Hour}
-	n := len(interval)
-	if n < 2 {
-		return 0, fmt.Errorf("invalid format: %s", interval)
+	if !p.strict {
+		interval = strings.TrimSpace(interval)
+	}
+	if interval == "" {
+		return 0, &ParseError{Input: interval, Err: ErrInvalidValue}
 	}
 
-	value, err := strconv.Atoi(interval[:n-1])
-	if err != nil || unitMap[interval[n-1]] == 0 {
-		return 0, fmt.Errorf("invalid duration: %s", interval)
+	var total, last time.Duration
+	for i := 0; i < len(interval); {
+		j := i + strings.IndexFunc(interval[i:], isNotDigit)
+		if j < i {
+			return 0, &ParseError{Input: interval, Index: len(interval), Err: ErrInvalidUnit, Suggestion: interval + "m"}
+		}
+		k := len(interval)
+		if n := strings.IndexFunc(interval[j:], isDigit); n >= 0 {
+			k = j + n
+		}
+		if j == i {
+			return 0, &ParseError{Input: interval, Token: interval[i:k], Index: i, Err: ErrInvalidValue}
+		}
+
+		unit := interval[j:k]
+		if len(unit) != 1 || unitMap[unit[0]] == 0 {
+			return 0, &ParseError{Input: interval, Token: unit, Index: j, Err: ErrInvalidUnit, Suggestion: suggestUnit(interval, i, j, k)}
+		}
+		// Values too large for a Duration, alone or in total, are rejected
+		// rather than left to wrap around.
+		scale := unitMap[unit[0]]
+		value, err := strconv.ParseInt(interval[i:j], 10, 64)
+		if err != nil || time.Duration(value) > (math.MaxInt64-total)/scale {
+			return 0, &ParseError{Input: interval, Token: interval[i:j], Index: i, Err: ErrInvalidValue}
+		}
+		if p.strict && last != 0 && scale >= last {
+			return 0, &ParseError{Input: interval, Token: unit, Index: j, Err: ErrInvalidUnit, Suggestion: suggestOrder(interval)}
+		}
+		total += time.Duration(value) * scale
+		last = scale
+		i = k
 	}
 
-	return time.Duration(value) * unitMap[interval[n-1]], nil
+	return total, nil
+}
+
+func isDigit(r rune) bool {
+	return r >= '0' && r <= '9'
+}
+
+func isNotDigit(r rune) bool {
+	return !isDigit(r)
+}
+
+func formatDuration(d time.Duration) string {
+	if d <= 0 || d%time.Second != 0 {
+		return d.String()
+	}
+
+	var b strings.Builder
+	for _, u := range []struct {
+		unit time.Duration
+		name string
+	}{{24 * time.Hour, "d"}, {time.Hour, "h"}, {time.Minute, "m"}, {time.Second, "s"}} {
+		if n := d / u.unit; n > 0 {
+			b.WriteString(strconv.FormatInt(int64(n), 10) + u.name)
+			d -= n * u.unit
+		}
+	}
+	return b.String()
+}
+
+func (t *Task) ID() TaskID {
+	return t.id
 }
 
+func (t *Task) Name() string {
+	return t.name
+}
+
+func (t *Task) Schedule() string {
+	return t.currentSchedule().String()
+}
+
+func (t *Task) currentSchedule() schedule {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.schedule
+}
+
+// Start starts the task's run loop. A task can only be started once;
+// starting it again, or after Stop, does nothing. TryStart reports those
+// cases as errors.
 func (t *Task) Start() {
+	t.TryStart()
+}
+
+// TryStart is Start returning ErrAlreadyRunning for a task already started
+// and ErrTaskStopped for a stopped one.
+func (t *Task) TryStart() error {
+	if t.stopping.Load() {
+		return ErrTaskStopped
+	}
+	if !t.launched.CompareAndSwap(false, true) {
+		return ErrAlreadyRunning
+	}
+	t.restoreSchedule()
+	if t.afterFunc {
+		t.startAfterFunc()
+		return nil
+	}
+
+	t.loopMu.Lock()
+	t.looping = true
+	t.loopMu.Unlock()
 	t.wg.Add(1)
 	go func() {
 		defer t.wg.Done()
+		defer t.exited()
 
-		t.timer = time.NewTimer(t.duration)
-
-		for {
-			select {
-			case <-t.stopChan:
-				t.timer.Stop()
-				return
-			case newDuration := <-t.updateChan:
-				t.timer.Stop()
-				t.duration = newDuration
-				t.timer.Reset(t.duration)
-			case <-t.timer.C:
-				t.taskFunc()
-				t.timer.Reset(t.duration)
-			}
+		if !t.warmUp() {
+			t.setState(StateStopped, time.Time{})
+			return
 		}
+		t.timer = timers.get()
+		for t.loop() {
+		}
+		timers.put(t.timer)
+		t.timer = nil
 	}()
+	return nil
+}
+
+// loop runs the task until it is stopped. Under a Supervisor it reports
+// whether it should be started again after a panic.
+func (t *Task) loop() (restart bool) {
+	if t.supervisor != nil {
+		defer func() {
+			if r := recover(); r != nil {
+				restart = t.crashed(r)
+			}
+		}()
+	}
+
+	ext := t.extTrigger
+	t.begin()
+	for {
+		select {
+		case <-t.stopChan:
+			t.end()
+			return false
+		case s := <-t.updateChan:
+			t.reschedule(s)
+			t.updated <- struct{}{}
+		case <-t.enableChan:
+			t.enable()
+			t.updated <- struct{}{}
+		case <-t.runChan:
+			t.runNow(false)
+		case <-t.triggerChan:
+			t.triggered()
+		case _, ok := <-ext:
+			if !ok {
+				ext = nil
+				continue
+			}
+			t.external()
+		case now := <-t.timer.C:
+			t.fire(now)
+		}
+	}
 }
 
 func (t *Task) Stop() {
-	close(t.stopChan)
+	t.stopOnce.Do(func() {
+		t.stopping.Store(true)
+		if t.unwatch != nil {
+			t.unwatch()
+		}
+		t.cancel()
+		close(t.stopChan)
+	})
 	t.wg.Wait()
+	if t.afterFunc {
+		t.stopAfterFunc()
+	}
+	t.asyncRuns.Wait()
+	t.stopped()
+	t.exited()
 }
 
+// UpdateInterval is Reschedule restricted to plain intervals.
 func (t *Task) UpdateInterval(interval string) error {
-	newDuration, err := parseDuration(interval)
+	s, err := t.parser.interval(interval)
 	if err != nil {
 		return err
 	}
+	return t.change(s)
+}
 
-	t.updateChan <- newDuration
-	return nil
+// Reschedule replaces the task's schedule with spec, which may use any
+// syntax NewTask accepts. The next fire is computed afresh from now; a run
+// in progress finishes first.
+func (t *Task) Reschedule(spec string) error {
+	s, err := t.parser.parse(spec)
+	if err != nil {
+		return err
+	}
+	return t.change(s)
+}
+
+// update hands s to the run loop, or sets it directly if the task has
+// not been started. It returns ErrTaskStopped once the loop has exited.
+func (t *Task) update(s schedule) error {
+	if t.afterFunc {
+		return t.updateAfterFunc(s)
+	}
+	if ok, err := t.unstarted(func() {
+		t.mu.Lock()
+		t.schedule = s
+		t.mu.Unlock()
+	}); ok {
+		return err
+	}
+	select {
+	case t.updateChan <- s:
+		<-t.updated
+		return nil
+	case <-t.stopChan:
+		return ErrTaskStopped
+	case <-t.done:
+		return ErrTaskStopped
+	}
+}
+
+// unstarted calls f under loopMu if the task's run loop has not been
+// started, reporting whether it was, or ErrTaskStopped for a task stopped
+// before it started.
+func (t *Task) unstarted(f func()) (bool, error) {
+	t.loopMu.Lock()
+	defer t.loopMu.Unlock()
+
+	if t.looping {
+		return false, nil
+	}
+	if t.stopping.Load() {
+		return true, ErrTaskStopped
+	}
+	f()
+	return true, nil
 }
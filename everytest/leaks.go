@@ -0,0 +1,99 @@
+// Package everytest provides helpers for testing code that uses every.
+package everytest
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/daifiyum/every"
+)
+
+// leakTimeout is how long VerifyNoLeaks waits for goroutines of stopped
+// tasks to finish exiting before it reports them.
+const leakTimeout = 2 * time.Second
+
+var pkgPath = reflect.TypeFor[every.Task]().PkgPath()
+
+// VerifyNoLeaks fails t if any goroutine started by every is still running,
+// which happens when a task is started but never stopped. Call it at the
+// end of a test, typically with defer, after stopping the test's tasks or
+// Scheduler. Goroutines take a moment to exit after Stop, so it retries
+// briefly before failing.
+func VerifyNoLeaks(t testing.TB) {
+	t.Helper()
+	if leaks := findLeaks(); len(leaks) > 0 {
+		t.Errorf("everytest: %d leaked goroutines:\n\n%s", len(leaks), strings.Join(leaks, "\n\n"))
+	}
+}
+
+// VerifyTestMain runs the tests in m and then fails the test binary if any
+// goroutine started by every is still running, so a package can check all
+// of its tests at once:
+//
+//	func TestMain(m *testing.M) {
+//		everytest.VerifyTestMain(m)
+//	}
+func VerifyTestMain(m *testing.M) {
+	code := m.Run()
+	if code == 0 {
+		if leaks := findLeaks(); len(leaks) > 0 {
+			fmt.Fprintf(os.Stderr, "everytest: %d leaked goroutines:\n\n%s\n", len(leaks), strings.Join(leaks, "\n\n"))
+			code = 1
+		}
+	}
+	os.Exit(code)
+}
+
+// findLeaks returns the stacks of leaked goroutines, waiting up to
+// leakTimeout for them to go away.
+func findLeaks() []string {
+	deadline := time.Now().Add(leakTimeout)
+	for delay := time.Millisecond; ; delay = min(2*delay, 100*time.Millisecond) {
+		leaks := leaked()
+		if len(leaks) == 0 || time.Now().After(deadline) {
+			return leaks
+		}
+		time.Sleep(delay)
+	}
+}
+
+// leaked returns the stacks of goroutines other than the caller's that are
+// running code from every, leaving out those running tests.
+func leaked() []string {
+	buf := make([]byte, 64<<10)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			buf = buf[:n]
+			break
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+
+	var leaks []string
+	// The first goroutine in the dump is the caller's own.
+	for _, g := range strings.Split(string(buf), "\n\n")[1:] {
+		if isLeak(g) {
+			leaks = append(leaks, g)
+		}
+	}
+	return leaks
+}
+
+func isLeak(stack string) bool {
+	if strings.Contains(stack, "\ntesting.") {
+		return false
+	}
+	for _, line := range strings.Split(stack, "\n") {
+		fn, ok := strings.CutPrefix(strings.TrimPrefix(line, "created by "), pkgPath)
+		if ok && (strings.HasPrefix(fn, ".") || strings.HasPrefix(fn, "/") && !strings.HasPrefix(fn, "/everytest.")) {
+			return true
+		}
+	}
+	return false
+}
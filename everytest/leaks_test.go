@@ -0,0 +1,42 @@
+package everytest
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/daifiyum/every"
+)
+
+func TestMain(m *testing.M) {
+	VerifyTestMain(m)
+}
+
+// recorder is a testing.TB that records failures instead of failing.
+type recorder struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func TestVerifyNoLeaks(t *testing.T) {
+	task, err := every.NewTask("1h", func() {})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := task.TryStart(); err != nil {
+		t.Fatal(err)
+	}
+	if leaks := leaked(); len(leaks) == 0 {
+		t.Error("running task's goroutine not reported")
+	}
+
+	task.Stop()
+	r := &recorder{TB: t}
+	VerifyNoLeaks(r)
+	if len(r.errors) > 0 {
+		t.Errorf("stopped task reported as leaked: %v", r.errors)
+	}
+}
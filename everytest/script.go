@@ -0,0 +1,100 @@
+package everytest
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/daifiyum/every"
+)
+
+// Outcome is one scripted run of a task.
+type Outcome struct {
+	err   error
+	panic any
+	delay time.Duration
+}
+
+// Succeed returns an Outcome of a successful run.
+func Succeed() Outcome {
+	return Outcome{}
+}
+
+// Fail returns an Outcome of a run failing with err.
+func Fail(err error) Outcome {
+	return Outcome{err: err}
+}
+
+// Panic returns an Outcome of a run panicking with v.
+func Panic(v any) Outcome {
+	return Outcome{panic: v}
+}
+
+// Slow returns an Outcome of a run that takes d and then ends as o. If the
+// run's context ends first, the run fails with the context's error, which
+// is how WithTimeout and Stop are seen.
+func Slow(d time.Duration, o Outcome) Outcome {
+	o.delay = d
+	return o
+}
+
+// Script is a task func acting out a fixed sequence of outcomes, for
+// driving retry, backoff and panic handling deterministically:
+//
+//	s := everytest.NewScript(everytest.Fail(err), everytest.Fail(err), everytest.Succeed())
+//	task, _ := every.NewTask("1s", nil, every.WithRetry(3, b), s.Option())
+//
+// Once the outcomes run out, the last one repeats; an empty Script
+// always succeeds.
+type Script struct {
+	mu       sync.Mutex
+	outcomes []Outcome
+	calls    int
+}
+
+func NewScript(outcomes ...Outcome) *Script {
+	return &Script{outcomes: outcomes}
+}
+
+// Option replaces a task's func with the script. It can be passed when the
+// task is created or to Clone to script an existing task.
+func (s *Script) Option() every.Option {
+	return every.WithFunc(s.Run)
+}
+
+// Run acts out the next outcome. It is the func Option installs.
+func (s *Script) Run(ctx context.Context) error {
+	o := s.next()
+	if o.delay > 0 {
+		timer := time.NewTimer(o.delay)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	if o.panic != nil {
+		panic(o.panic)
+	}
+	return o.err
+}
+
+// Calls returns how many times the script has been run, counting retries.
+func (s *Script) Calls() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.calls
+}
+
+func (s *Script) next() Outcome {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.calls++
+	if len(s.outcomes) == 0 {
+		return Outcome{}
+	}
+	return s.outcomes[min(s.calls, len(s.outcomes))-1]
+}
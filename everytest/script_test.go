@@ -0,0 +1,59 @@
+package everytest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestScript(t *testing.T) {
+	errFlaky := errors.New("flaky")
+	for _, tc := range []struct {
+		name     string
+		outcomes []Outcome
+		want     []error
+	}{
+		{"empty", nil, []error{nil, nil}},
+		{"sequence", []Outcome{Fail(errFlaky), Succeed()}, []error{errFlaky, nil}},
+		{"last repeats", []Outcome{Succeed(), Fail(errFlaky)}, []error{nil, errFlaky, errFlaky}},
+		{"slow", []Outcome{Slow(time.Millisecond, Fail(errFlaky))}, []error{errFlaky}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			s := NewScript(tc.outcomes...)
+			for i, want := range tc.want {
+				if err := s.Run(context.Background()); !errors.Is(err, want) {
+					t.Errorf("run %d: %v, want %v", i+1, err, want)
+				}
+			}
+			if s.Calls() != len(tc.want) {
+				t.Errorf("Calls() = %d, want %d", s.Calls(), len(tc.want))
+			}
+		})
+	}
+}
+
+func TestScriptPanic(t *testing.T) {
+	s := NewScript(Panic("boom"), Succeed())
+	func() {
+		defer func() {
+			if r := recover(); r != "boom" {
+				t.Errorf("recovered %v, want boom", r)
+			}
+		}()
+		s.Run(context.Background())
+		t.Error("Run did not panic")
+	}()
+	if err := s.Run(context.Background()); err != nil {
+		t.Errorf("run after the panic: %v", err)
+	}
+}
+
+func TestScriptSlowCancelled(t *testing.T) {
+	s := NewScript(Slow(time.Hour, Succeed()))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := s.Run(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Run() = %v, want the context's error", err)
+	}
+}
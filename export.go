@@ -0,0 +1,86 @@
+package every
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+type ScheduleEntry struct {
+	Name     string `json:"name"`
+	Schedule string `json:"schedule"`
+	Crontab  string `json:"crontab,omitempty"`
+}
+
+func (s *Scheduler) Export() []ScheduleEntry {
+	var entries []ScheduleEntry
+	for _, t := range s.Tasks() {
+		sched := t.currentSchedule()
+		crontab, _ := crontabSpec(sched)
+		entries = append(entries, ScheduleEntry{
+			Name:     t.name,
+			Schedule: sched.String(),
+			Crontab:  crontab,
+		})
+	}
+	return entries
+}
+
+func (s *Scheduler) ExportJSON() ([]byte, error) {
+	return json.MarshalIndent(s.Export(), "", "  ")
+}
+
+// ExportCrontab renders every task as a crontab line, using the task name
+// as the command. Schedules cron cannot express are written as comments.
+func (s *Scheduler) ExportCrontab() string {
+	var b strings.Builder
+	for _, e := range s.Export() {
+		if e.Crontab == "" {
+			fmt.Fprintf(&b, "# every %s %s\n", e.Schedule, e.Name)
+			continue
+		}
+		fmt.Fprintf(&b, "%s %s\n", e.Crontab, e.Name)
+	}
+	return b.String()
+}
+
+// crontabSpec converts s to a five-field cron expression. Intervals only
+// convert when they evenly divide the next larger unit, and even then they
+// become clock-aligned rather than measured from the previous run.
+func crontabSpec(s schedule) (string, bool) {
+	switch s := s.(type) {
+	case *cronSchedule:
+		if !s.withSeconds {
+			return s.spec, true
+		}
+		if s.second == 1 {
+			return strings.Join(strings.Fields(s.spec)[1:], " "), true
+		}
+	case weeklySchedule:
+		return fmt.Sprintf("%d %d * * %d", s.minute, s.hour, s.weekday), true
+	case businessSchedule:
+		return fmt.Sprintf("%d %d * * 1-5", s.minute, s.hour), true
+	case dailySchedule:
+		return fmt.Sprintf("%d %d * * *", s.minute, s.hour), true
+	case dayCycleSchedule:
+		if s.days == 1 {
+			return fmt.Sprintf("%d %d * * *", s.minute, s.hour), true
+		}
+	case intervalSchedule:
+		d := time.Duration(s)
+		switch {
+		case d == time.Minute:
+			return "* * * * *", true
+		case d < time.Hour && d%time.Minute == 0 && time.Hour%d == 0:
+			return fmt.Sprintf("*/%d * * * *", d/time.Minute), true
+		case d == time.Hour:
+			return "0 * * * *", true
+		case d < 24*time.Hour && d%time.Hour == 0 && 24*time.Hour%d == 0:
+			return fmt.Sprintf("0 */%d * * *", d/time.Hour), true
+		case d == 24*time.Hour:
+			return "0 0 * * *", true
+		}
+	}
+	return "", false
+}
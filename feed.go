@@ -0,0 +1,93 @@
+package every
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+)
+
+// OverflowPolicy decides what a Feed does with a value when its buffer is
+// full because the reader is not keeping up.
+type OverflowPolicy int
+
+const (
+	// OverflowBlock waits for room, giving up on the value if the
+	// sender's context ends first.
+	OverflowBlock OverflowPolicy = iota
+	// OverflowDropOldest discards the oldest buffered value to make room.
+	OverflowDropOldest
+	// OverflowDropNewest discards the value being sent.
+	OverflowDropNewest
+)
+
+// Feed is a buffered channel of values from a task, with a policy for
+// readers that fall behind. Values given up on are counted by Dropped.
+type Feed[T any] struct {
+	ch      chan T
+	policy  OverflowPolicy
+	mu      sync.Mutex
+	dropped atomic.Uint64
+}
+
+func newFeed[T any](size int, policy OverflowPolicy) *Feed[T] {
+	return &Feed[T]{ch: make(chan T, max(size, 0)), policy: policy}
+}
+
+// C returns the channel to read values from.
+func (f *Feed[T]) C() <-chan T {
+	return f.ch
+}
+
+// Dropped returns how many values were discarded or given up on.
+func (f *Feed[T]) Dropped() uint64 {
+	return f.dropped.Load()
+}
+
+func (f *Feed[T]) send(ctx context.Context, v T) {
+	switch f.policy {
+	case OverflowDropOldest:
+		// Senders take turns so a value dropped to make room is not
+		// taken by another sender first.
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		for {
+			select {
+			case f.ch <- v:
+				return
+			default:
+			}
+			select {
+			case <-f.ch:
+				f.dropped.Add(1)
+			default:
+			}
+		}
+	case OverflowDropNewest:
+		select {
+		case f.ch <- v:
+		default:
+			f.dropped.Add(1)
+		}
+	default:
+		select {
+		case f.ch <- v:
+		case <-ctx.Done():
+			f.dropped.Add(1)
+		}
+	}
+}
+
+// ChannelNotifier is a Notifier delivering events on a Feed, for programs
+// that would rather read events than implement Notifier.
+type ChannelNotifier struct {
+	*Feed[Event]
+}
+
+func NewChannelNotifier(size int, policy OverflowPolicy) *ChannelNotifier {
+	return &ChannelNotifier{newFeed[Event](size, policy)}
+}
+
+func (n *ChannelNotifier) Notify(ctx context.Context, e Event) error {
+	n.send(ctx, e)
+	return nil
+}
@@ -0,0 +1,88 @@
+package every
+
+import (
+	"slices"
+)
+
+// fence is a mutual exclusion group shared by a Scheduler's tasks.
+type fence struct {
+	name string
+	held chan struct{}
+}
+
+// WithFence puts the task in the named mutual exclusion group of the
+// Scheduler it is added to: no two tasks in a group run at the same time,
+// as with "db-vacuum" and "db-backup". A fire that finds the group busy
+// waits for it, or is skipped as SkipFenced if skip is set. A task may be
+// in several groups; skip applies to all of them. Tasks outside a
+// Scheduler are not fenced.
+func WithFence(group string, skip bool) Option {
+	return func(t *Task) {
+		if !slices.Contains(t.fenceNames, group) {
+			t.fenceNames = append(slices.Clone(t.fenceNames), group)
+		}
+		t.fenceSkip = skip
+	}
+}
+
+// fencesLocked returns the fences called names, creating them as needed,
+// sorted by name so that tasks in several groups lock them in one order.
+func (s *Scheduler) fencesLocked(names []string) []*fence {
+	if len(names) == 0 {
+		return nil
+	}
+	if s.fences == nil {
+		s.fences = make(map[string]*fence)
+	}
+	fences := make([]*fence, 0, len(names))
+	for _, name := range names {
+		f, ok := s.fences[name]
+		if !ok {
+			f = &fence{name: name, held: make(chan struct{}, 1)}
+			s.fences[name] = f
+		}
+		fences = append(fences, f)
+	}
+	slices.SortFunc(fences, func(a, b *fence) int {
+		switch {
+		case a.name < b.name:
+			return -1
+		case a.name > b.name:
+			return 1
+		}
+		return 0
+	})
+	return fences
+}
+
+// lockFences takes all of the task's fences. It returns false, holding
+// none of them, if one is busy and the task skips, or if the task is
+// stopped while waiting.
+func (t *Task) lockFences() bool {
+	for i, f := range t.fences {
+		if t.fenceSkip {
+			select {
+			case f.held <- struct{}{}:
+				continue
+			default:
+			}
+		} else {
+			select {
+			case f.held <- struct{}{}:
+				continue
+			case <-t.ctx.Done():
+			}
+		}
+		for _, f := range t.fences[:i] {
+			<-f.held
+		}
+		return false
+	}
+	return true
+}
+
+func (t *Task) unlockFences() {
+	for _, f := range t.fences {
+		<-f.held
+	}
+}
@@ -0,0 +1,106 @@
+package every
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFenceOrder(t *testing.T) {
+	s := NewScheduler()
+	a, _ := NewTask("1h", func() {}, WithFence("db", false), WithFence("cache", false))
+	b, _ := NewTask("1h", func() {}, WithFence("cache", false), WithFence("db", false), WithFence("db", false))
+	s.Add(a)
+	s.Add(b)
+
+	for _, task := range []*Task{a, b} {
+		if len(task.fences) != 2 || task.fences[0].name != "cache" || task.fences[1].name != "db" {
+			t.Fatalf("fences not sorted by name: %v", task.fences)
+		}
+	}
+	if a.fences[0] != b.fences[0] || a.fences[1] != b.fences[1] {
+		t.Error("tasks in the same groups do not share their fences")
+	}
+}
+
+func TestFenceLock(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		held  []string
+		opts  []Option
+		stop  bool
+		want  bool
+		after []string
+	}{
+		{"free", nil, []Option{WithFence("db", true)}, false, true, []string{"db"}},
+		{"busy skips", []string{"db"}, []Option{WithFence("db", true)}, false, false, []string{"db"}},
+		{"other group", []string{"cache"}, []Option{WithFence("db", true)}, false, true, []string{"cache", "db"}},
+		// A task in several groups holds none of them when one is busy.
+		{"partly busy", []string{"db"}, []Option{WithFence("db", true), WithFence("cache", true)}, false, false, []string{"db"}},
+		{"stopped waiting", []string{"db"}, []Option{WithFence("cache", false), WithFence("db", false)}, true, false, []string{"db"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			s := NewScheduler()
+			holder, _ := NewTask("1h", func() {}, WithFence("cache", true), WithFence("db", true))
+			s.Add(holder)
+			for _, f := range holder.fences {
+				for _, name := range tc.held {
+					if f.name == name {
+						f.held <- struct{}{}
+					}
+				}
+			}
+			task, _ := NewTask("1h", func() {}, tc.opts...)
+			s.Add(task)
+			if tc.stop {
+				time.AfterFunc(10*time.Millisecond, task.cancel)
+			}
+
+			if got := task.lockFences(); got != tc.want {
+				t.Errorf("lockFences() = %v, want %v", got, tc.want)
+			}
+			var held []string
+			for _, f := range holder.fences {
+				if len(f.held) == 1 {
+					held = append(held, f.name)
+				}
+			}
+			if len(held) != len(tc.after) {
+				t.Fatalf("held %v after lockFences, want %v", held, tc.after)
+			}
+			for i := range held {
+				if held[i] != tc.after[i] {
+					t.Errorf("held %v after lockFences, want %v", held, tc.after)
+				}
+			}
+		})
+	}
+}
+
+func TestFenceWait(t *testing.T) {
+	s := NewScheduler()
+	vacuum, _ := NewTask("1h", func() {}, WithFence("db", false))
+	backup, _ := NewTask("1h", func() {}, WithFence("db", false))
+	s.Add(vacuum)
+	s.Add(backup)
+
+	if !vacuum.lockFences() {
+		t.Fatal("lockFences failed on a free fence")
+	}
+	locked := make(chan bool)
+	go func() { locked <- backup.lockFences() }()
+	select {
+	case <-locked:
+		t.Fatal("second task took a held fence")
+	case <-time.After(10 * time.Millisecond):
+	}
+	vacuum.unlockFences()
+	select {
+	case ok := <-locked:
+		if !ok {
+			t.Error("waiting task gave up on the fence")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("waiting task did not get the released fence")
+	}
+	backup.unlockFences()
+}
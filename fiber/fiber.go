@@ -0,0 +1,31 @@
+// Package everyfiber runs a Scheduler inside a Fiber app. It is a module
+// of its own to keep Fiber out of programs that do not use it.
+package everyfiber
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/daifiyum/every"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+)
+
+// Register ties s to app: its tasks start when app starts listening and
+// stop once app has shut down, and its AdminHandler is served under
+// prefix, such as "/admin/every", behind handlers. The admin endpoints
+// have no authentication of their own, so handlers should provide it.
+func Register(app *fiber.App, prefix string, s *every.Scheduler, handlers ...fiber.Handler) {
+	app.Hooks().OnListen(func(fiber.ListenData) error {
+		s.StartAll()
+		return nil
+	})
+	app.Hooks().OnShutdown(func() error {
+		s.StopAll()
+		return nil
+	})
+
+	prefix = strings.TrimSuffix(prefix, "/")
+	admin := adaptor.HTTPHandler(http.StripPrefix(prefix, s.AdminHandler()))
+	app.Group(prefix, handlers...).Use(admin)
+}
@@ -0,0 +1,67 @@
+package everyfiber
+
+import (
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/daifiyum/every"
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestRegister(t *testing.T) {
+	s := every.NewScheduler()
+	task, _ := every.NewTask("1h", func() {})
+	s.Add(task)
+
+	app := fiber.New(fiber.Config{DisableStartupMessage: true})
+	Register(app, "/admin/every/", s, func(c *fiber.Ctx) error {
+		if c.Get("Authorization") != "secret" {
+			return fiber.ErrUnauthorized
+		}
+		return c.Next()
+	})
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go app.Listener(ln)
+
+	deadline := time.Now().Add(time.Second)
+	for task.State() != every.StateWaiting {
+		if time.Now().After(deadline) {
+			t.Fatal("task not started with the app")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	url := "http://" + ln.Addr().String() + "/admin/every/tasks/1/pause"
+	for _, tc := range []struct {
+		auth string
+		code int
+	}{{"", http.StatusUnauthorized}, {"secret", http.StatusOK}} {
+		req, _ := http.NewRequest("POST", url, nil)
+		// Shutdown waits for keep-alive connections to close.
+		req.Close = true
+		req.Header.Set("Authorization", tc.auth)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != tc.code {
+			t.Errorf("auth %q: status %d, want %d", tc.auth, resp.StatusCode, tc.code)
+		}
+	}
+	if !task.Paused() {
+		t.Error("admin endpoint did not pause the task")
+	}
+
+	if err := app.Shutdown(); err != nil {
+		t.Fatal(err)
+	}
+	if task.State() != every.StateStopped {
+		t.Errorf("state %v after the app shut down, want stopped", task.State())
+	}
+}
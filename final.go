@@ -0,0 +1,44 @@
+package every
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// finalRunTimeout bounds a final run for tasks without WithTimeout.
+const finalRunTimeout = 30 * time.Second
+
+// WithFinalRun makes Stop run a started task one last time before
+// returning, for tasks that buffer work and must flush it on exit. The
+// final run's context is not cancelled by Stop but expires after the
+// task's WithTimeout, or 30s without one.
+func WithFinalRun() Option {
+	return func(t *Task) {
+		t.final = true
+	}
+}
+
+func (t *Task) finalRun() {
+	limit := t.timeout
+	if limit <= 0 {
+		limit = finalRunTimeout
+	}
+	ctx, cancel := context.WithTimeoutCause(context.WithoutCancel(t.runCtx), limit, ErrTimeout)
+	defer cancel()
+
+	start := time.Now()
+	err := t.run(ctx, start, start)
+	t.subs.publish(ctx)
+	end := time.Now()
+	if err == nil && context.Cause(ctx) == ErrTimeout {
+		err = fmt.Errorf("%w after %s", ErrTimeout, limit)
+	}
+	if t.audit != nil || t.historySink != nil {
+		t.record(t.runs, start, start, end, err)
+	}
+	if err != nil {
+		t.log.Error("task final run failed", "error", err, "duration", end.Sub(start))
+	}
+	t.stats.ran(end.Sub(start), err)
+}
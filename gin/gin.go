@@ -0,0 +1,28 @@
+// Package everygin runs a Scheduler alongside a Gin engine. It is a module
+// of its own to keep Gin out of programs that do not use it.
+package everygin
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/daifiyum/every"
+	"github.com/gin-gonic/gin"
+)
+
+// Register serves s's AdminHandler under the route group g, such as
+// router.Group("/admin/every", auth). The admin endpoints have no
+// authentication of their own, so the group's middleware should provide
+// it.
+func Register(g *gin.RouterGroup, s *every.Scheduler) {
+	prefix := strings.TrimSuffix(g.BasePath(), "/")
+	g.Any("/*path", gin.WrapH(http.StripPrefix(prefix, s.AdminHandler())))
+}
+
+// Run is r.Run for an engine with a Scheduler: it starts s's tasks, then
+// serves r on addr, stopping the tasks again if serving fails. Gin has no
+// startup or shutdown hooks of its own; to shut down gracefully, build an
+// http.Server with r.Handler() and serve it with s.ListenAndServe.
+func Run(r *gin.Engine, addr string, s *every.Scheduler) error {
+	return s.ListenAndServe(&http.Server{Addr: addr, Handler: r.Handler()})
+}
@@ -0,0 +1,53 @@
+package everygin
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/daifiyum/every"
+	"github.com/gin-gonic/gin"
+)
+
+func TestRegister(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	s := every.NewScheduler()
+	task, _ := every.NewTask("1h", func() {})
+	s.Add(task)
+
+	r := gin.New()
+	Register(r.Group("/admin/every", func(c *gin.Context) {
+		if c.GetHeader("Authorization") != "secret" {
+			c.AbortWithStatus(http.StatusUnauthorized)
+		}
+	}), s)
+
+	for _, tc := range []struct {
+		auth string
+		code int
+	}{{"", http.StatusUnauthorized}, {"secret", http.StatusOK}} {
+		req := httptest.NewRequest("POST", "/admin/every/tasks/1/pause", nil)
+		req.Header.Set("Authorization", tc.auth)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		if w.Code != tc.code {
+			t.Errorf("auth %q: status %d, want %d", tc.auth, w.Code, tc.code)
+		}
+	}
+	if !task.Paused() {
+		t.Error("admin endpoint did not pause the task")
+	}
+}
+
+func TestRun(t *testing.T) {
+	s := every.NewScheduler()
+	task, _ := every.NewTask("1h", func() {})
+	s.Add(task)
+
+	if err := Run(gin.New(), "bad address", s); err == nil {
+		t.Fatal("Run on a bad address succeeded")
+	}
+	if task.State() != every.StateStopped {
+		t.Errorf("state %v after Run failed, want stopped", task.State())
+	}
+}
@@ -0,0 +1,99 @@
+package every
+
+import (
+	"log/slog"
+	"slices"
+	"time"
+)
+
+// overdueAfter is how far past its next fire a waiting task must be to
+// count as overdue in GroupStats.
+const overdueAfter = time.Minute
+
+// GroupStats sums up the Stats of a group of tasks, such as a Scheduler's
+// or a Namespace's, for one health metric or log line per service.
+type GroupStats struct {
+	Tasks  int   `json:"tasks"`
+	Runs   int64 `json:"runs"`
+	Skips  int64 `json:"skips"`
+	Errors int64 `json:"errors"`
+	// Failing counts tasks whose latest run failed; Overdue counts tasks
+	// waiting on a fire more than a minute past due.
+	Failing int `json:"failing"`
+	Overdue int `json:"overdue"`
+	// Busiest is the task that has spent the most time running; Top lists
+	// the tasks that have, busiest first.
+	Busiest string     `json:"busiest,omitempty"`
+	Top     []TaskLoad `json:"top,omitempty"`
+}
+
+// TaskLoad is one entry of GroupStats.Top.
+type TaskLoad struct {
+	Name   string        `json:"name"`
+	Runs   int64         `json:"runs"`
+	Errors int64         `json:"errors"`
+	Total  time.Duration `json:"total"`
+}
+
+// GroupStats sums up the Stats of all the Scheduler's tasks, listing the
+// top busiest in Top.
+func (s *Scheduler) GroupStats(top int) GroupStats {
+	return groupStats(s.Tasks(), top)
+}
+
+// Stats sums up the Stats of the namespace's tasks, listing the top
+// busiest in Top.
+func (n *Namespace) Stats(top int) GroupStats {
+	return groupStats(n.Tasks(), top)
+}
+
+func groupStats(tasks []*Task, top int) GroupStats {
+	g := GroupStats{Tasks: len(tasks)}
+	loads := make([]TaskLoad, 0, len(tasks))
+	now := time.Now()
+	for _, t := range tasks {
+		st := t.Stats()
+		g.Runs += st.Runs
+		g.Skips += st.Skips
+		g.Errors += st.Errors
+		if t.stats.failing.Load() {
+			g.Failing++
+		}
+		if next := t.nextRun.Load(); t.State() == StateWaiting && !next.IsZero() && now.Sub(next) > overdueAfter {
+			g.Overdue++
+		}
+		name := t.name
+		if name == "" {
+			name = t.String()
+		}
+		loads = append(loads, TaskLoad{Name: name, Runs: st.Runs, Errors: st.Errors, Total: st.TotalDuration})
+	}
+
+	slices.SortStableFunc(loads, func(a, b TaskLoad) int {
+		switch {
+		case a.Total > b.Total:
+			return -1
+		case a.Total < b.Total:
+			return 1
+		}
+		return 0
+	})
+	if len(loads) > 0 && loads[0].Total > 0 {
+		g.Busiest = loads[0].Name
+	}
+	g.Top = loads[:min(max(top, 0), len(loads))]
+	return g
+}
+
+// LogValue logs the totals and the busiest task, leaving out Top.
+func (g GroupStats) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Int("tasks", g.Tasks),
+		slog.Int64("runs", g.Runs),
+		slog.Int64("skips", g.Skips),
+		slog.Int64("errors", g.Errors),
+		slog.Int("failing", g.Failing),
+		slog.Int("overdue", g.Overdue),
+		slog.String("busiest", g.Busiest),
+	)
+}
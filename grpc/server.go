@@ -0,0 +1,155 @@
+// Package everygrpc serves a Scheduler's control plane over gRPC, so an
+// operations tool can list, pause, trigger and reschedule the tasks of
+// schedulers embedded in many services. It is a module of its own to keep
+// gRPC out of programs that only need the scheduler.
+package everygrpc
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative everypb/every.proto
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/daifiyum/every"
+	"github.com/daifiyum/every/grpc/everypb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Register serves s's control plane on r, typically a *grpc.Server. The
+// service has no authentication of its own; use the server's credentials
+// and interceptors to restrict who may call it.
+func Register(r grpc.ServiceRegistrar, s *every.Scheduler) {
+	everypb.RegisterSchedulerServer(r, &server{s: s})
+}
+
+type server struct {
+	everypb.UnimplementedSchedulerServer
+	s *every.Scheduler
+}
+
+func (srv *server) ListTasks(_ context.Context, req *everypb.ListTasksRequest) (*everypb.ListTasksResponse, error) {
+	resp := &everypb.ListTasksResponse{}
+	for _, t := range srv.s.Tasks() {
+		if req.Namespace != "" && t.Namespace() != req.Namespace {
+			continue
+		}
+		if req.Tag != "" && !t.HasTag(req.Tag) {
+			continue
+		}
+		resp.Tasks = append(resp.Tasks, task(t))
+	}
+	return resp, nil
+}
+
+func (srv *server) GetTask(_ context.Context, req *everypb.TaskRequest) (*everypb.Task, error) {
+	t, err := srv.find(req.Id, req.Name)
+	if err != nil {
+		return nil, err
+	}
+	return task(t), nil
+}
+
+func (srv *server) PauseTask(_ context.Context, req *everypb.TaskRequest) (*everypb.Task, error) {
+	t, err := srv.find(req.Id, req.Name)
+	if err != nil {
+		return nil, err
+	}
+	t.Pause()
+	return task(t), nil
+}
+
+func (srv *server) ResumeTask(_ context.Context, req *everypb.TaskRequest) (*everypb.Task, error) {
+	t, err := srv.find(req.Id, req.Name)
+	if err != nil {
+		return nil, err
+	}
+	t.Resume()
+	return task(t), nil
+}
+
+func (srv *server) TriggerTask(_ context.Context, req *everypb.TaskRequest) (*everypb.Task, error) {
+	t, err := srv.find(req.Id, req.Name)
+	if err != nil {
+		return nil, err
+	}
+	if err := t.RunNow(); err != nil {
+		return nil, statusOf(err)
+	}
+	return task(t), nil
+}
+
+func (srv *server) UpdateInterval(_ context.Context, req *everypb.UpdateIntervalRequest) (*everypb.Task, error) {
+	t, err := srv.find(req.Id, req.Name)
+	if err != nil {
+		return nil, err
+	}
+	if err := t.UpdateInterval(req.Interval); err != nil {
+		return nil, statusOf(err)
+	}
+	return task(t), nil
+}
+
+// find returns the task with the given id, or when id is 0 the first one
+// called name.
+func (srv *server) find(id uint64, name string) (*every.Task, error) {
+	if id != 0 {
+		if t, ok := srv.s.Task(every.TaskID(id)); ok {
+			return t, nil
+		}
+		return nil, status.Errorf(codes.NotFound, "no task with id %d", id)
+	}
+	if name == "" {
+		return nil, status.Error(codes.InvalidArgument, "task id or name required")
+	}
+	for _, t := range srv.s.Tasks() {
+		if t.Name() == name {
+			return t, nil
+		}
+	}
+	return nil, status.Errorf(codes.NotFound, "no task named %q", name)
+}
+
+// statusOf maps the scheduler's errors to gRPC status codes.
+func statusOf(err error) error {
+	code := codes.Internal
+	switch {
+	case errors.Is(err, every.ErrInvalidUnit), errors.Is(err, every.ErrInvalidValue),
+		errors.Is(err, every.ErrInvalidSchedule):
+		code = codes.InvalidArgument
+	case errors.Is(err, every.ErrTaskStopped), errors.Is(err, every.ErrNotStarted):
+		code = codes.FailedPrecondition
+	}
+	return status.Error(code, err.Error())
+}
+
+func task(t *every.Task) *everypb.Task {
+	snap := t.Snapshot()
+	return &everypb.Task{
+		Id:        uint64(snap.ID),
+		Name:      snap.Name,
+		Namespace: t.Namespace(),
+		Tags:      snap.Tags,
+		Schedule:  snap.Schedule,
+		State:     snap.State.String(),
+		Paused:    snap.Paused,
+		NextRun:   timestamp(snap.NextRun),
+		LastRun:   timestamp(snap.LastRun),
+		Runs:      snap.Stats.Runs,
+		Skips:     snap.Stats.Skips,
+		Errors:    snap.Stats.Errors,
+		LastError: snap.Stats.LastError,
+		Version:   t.Version(),
+	}
+}
+
+// timestamp converts t, leaving the zero time unset.
+func timestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
@@ -0,0 +1,122 @@
+package everygrpc
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/daifiyum/every"
+	"github.com/daifiyum/every/grpc/everypb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func client(t *testing.T, s *every.Scheduler) everypb.SchedulerClient {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	g := grpc.NewServer()
+	Register(g, s)
+	go g.Serve(lis)
+	t.Cleanup(g.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return everypb.NewSchedulerClient(conn)
+}
+
+func TestServer(t *testing.T) {
+	ran := make(chan struct{}, 1)
+	s := every.NewScheduler()
+	report, _ := every.NewTask("1h", func() { ran <- struct{}{} }, every.WithName("report"), every.WithTags("nightly"))
+	cleanup, _ := every.NewTask("5m", func() {}, every.WithName("cleanup"))
+	s.Add(report)
+	s.Add(cleanup)
+	s.StartAll()
+	defer s.StopAll()
+	c := client(t, s)
+	ctx := context.Background()
+
+	list, err := c.ListTasks(ctx, &everypb.ListTasksRequest{Tag: "nightly"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Tasks) != 1 || list.Tasks[0].Name != "report" || list.Tasks[0].Schedule != "1h" {
+		t.Fatalf("ListTasks(nightly) = %v", list.Tasks)
+	}
+
+	got, err := c.PauseTask(ctx, &everypb.TaskRequest{Name: "cleanup"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.Paused || !cleanup.Paused() {
+		t.Errorf("PauseTask left the task running: %v", got)
+	}
+	if got, err = c.ResumeTask(ctx, &everypb.TaskRequest{Id: got.Id}); err != nil || got.Paused {
+		t.Errorf("ResumeTask = %v, %v", got, err)
+	}
+
+	if _, err := c.TriggerTask(ctx, &everypb.TaskRequest{Name: "report"}); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-ran:
+	case <-time.After(time.Second):
+		t.Error("TriggerTask did not run the task")
+	}
+
+	got, err = c.UpdateInterval(ctx, &everypb.UpdateIntervalRequest{Name: "cleanup", Interval: "10m"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Schedule != "10m" || got.Version != 2 {
+		t.Errorf("UpdateInterval = %v", got)
+	}
+}
+
+func TestServerErrors(t *testing.T) {
+	s := every.NewScheduler()
+	task, _ := every.NewTask("1h", func() {}, every.WithName("report"))
+	s.Add(task)
+	c := client(t, s)
+	ctx := context.Background()
+
+	for _, tc := range []struct {
+		name string
+		call func() error
+		code codes.Code
+	}{
+		{"unknown id", func() error {
+			_, err := c.GetTask(ctx, &everypb.TaskRequest{Id: 99})
+			return err
+		}, codes.NotFound},
+		{"unknown name", func() error {
+			_, err := c.GetTask(ctx, &everypb.TaskRequest{Name: "missing"})
+			return err
+		}, codes.NotFound},
+		{"no task", func() error {
+			_, err := c.PauseTask(ctx, &everypb.TaskRequest{})
+			return err
+		}, codes.InvalidArgument},
+		{"bad interval", func() error {
+			_, err := c.UpdateInterval(ctx, &everypb.UpdateIntervalRequest{Name: "report", Interval: "5w"})
+			return err
+		}, codes.InvalidArgument},
+		{"not started", func() error {
+			_, err := c.TriggerTask(ctx, &everypb.TaskRequest{Name: "report"})
+			return err
+		}, codes.FailedPrecondition},
+	} {
+		if got := status.Code(tc.call()); got != tc.code {
+			t.Errorf("%s: code %v, want %v", tc.name, got, tc.code)
+		}
+	}
+}
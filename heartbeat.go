@@ -0,0 +1,51 @@
+package every
+
+import (
+	"net/http"
+	"os"
+	"time"
+)
+
+// WithHeartbeat calls fn from the task's run loop after every successful
+// run.
+func WithHeartbeat(fn func()) Option {
+	return func(t *Task) {
+		t.heartbeat = fn
+	}
+}
+
+// WithHeartbeatURL sends a GET to url after every successful run, for dead
+// man's switch monitors such as healthchecks.io that alert when pings stop
+// arriving. Pings are sent in the background; failures are logged.
+func WithHeartbeatURL(url string) Option {
+	client := &http.Client{Timeout: webhookTimeout}
+	return func(t *Task) {
+		t.heartbeat = func() {
+			go func() {
+				resp, err := client.Get(url)
+				if err != nil {
+					t.Logger().Warn("heartbeat failed", "error", err)
+					return
+				}
+				resp.Body.Close()
+				if resp.StatusCode >= 300 {
+					t.Logger().Warn("heartbeat failed", "status", resp.Status)
+				}
+			}()
+		}
+	}
+}
+
+// WithHeartbeatFile writes the current time to path after every successful
+// run, for container liveness probes that check how recently a file was
+// modified. Write failures are logged.
+func WithHeartbeatFile(path string) Option {
+	return func(t *Task) {
+		t.heartbeat = func() {
+			stamp := time.Now().UTC().AppendFormat(nil, time.RFC3339Nano)
+			if err := os.WriteFile(path, append(stamp, '\n'), 0o644); err != nil {
+				t.Logger().Warn("heartbeat failed", "error", err)
+			}
+		}
+	}
+}
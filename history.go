@@ -0,0 +1,116 @@
+package every
+
+import (
+	"database/sql"
+	"time"
+)
+
+// HistorySink records every run of the tasks using it. AuditLog is one.
+type HistorySink interface {
+	Write(r AuditRecord) error
+}
+
+// WithHistory records every run of the task in sink, like WithAuditLog.
+func WithHistory(sink HistorySink) Option {
+	return func(t *Task) {
+		t.historySink = sink
+	}
+}
+
+// sqliteTime is how SQLHistory stores times: UTC in SQLite's own format,
+// so they compare correctly with the results of datetime('now', ...).
+const sqliteTime = "2006-01-02 15:04:05.000"
+
+// SQLHistory is a HistorySink keeping runs in the every_runs table of an
+// SQLite database, opened with the driver of the caller's choice. Times
+// are stored as UTC text and durations in nanoseconds, so questions such
+// as how long nightly-report has been taking over the last month are a
+// query away:
+//
+//	SELECT date(started_at), avg(duration_ns) / 1e9 FROM every_runs
+//	WHERE task = 'nightly-report' AND started_at > datetime('now', '-1 month')
+//	GROUP BY 1
+type SQLHistory struct {
+	db     *sql.DB
+	insert *sql.Stmt
+}
+
+// NewSQLHistory creates the every_runs table in db unless it exists.
+func NewSQLHistory(db *sql.DB) (*SQLHistory, error) {
+	_, err := db.Exec(`CREATE TABLE IF NOT EXISTS every_runs (
+	task         TEXT NOT NULL,
+	run          INTEGER NOT NULL,
+	scheduled_at TEXT,
+	started_at   TEXT NOT NULL,
+	ended_at     TEXT NOT NULL,
+	duration_ns  INTEGER NOT NULL,
+	error        TEXT
+)`)
+	if err == nil {
+		_, err = db.Exec(`CREATE INDEX IF NOT EXISTS every_runs_task_started ON every_runs (task, started_at)`)
+	}
+	if err != nil {
+		return nil, err
+	}
+	insert, err := db.Prepare(`INSERT INTO every_runs
+	(task, run, scheduled_at, started_at, ended_at, duration_ns, error)
+	VALUES (?, ?, ?, ?, ?, ?, ?)`)
+	if err != nil {
+		return nil, err
+	}
+	return &SQLHistory{db: db, insert: insert}, nil
+}
+
+func (h *SQLHistory) Write(r AuditRecord) error {
+	var scheduled, failure any
+	if !r.Scheduled.IsZero() {
+		scheduled = r.Scheduled.UTC().Format(sqliteTime)
+	}
+	if r.Error != "" {
+		failure = r.Error
+	}
+	_, err := h.insert.Exec(r.Task, int64(r.Run), scheduled,
+		r.Start.UTC().Format(sqliteTime), r.End.UTC().Format(sqliteTime),
+		int64(r.Duration), failure)
+	return err
+}
+
+// Close releases the prepared statement; the database is left open.
+func (h *SQLHistory) Close() error {
+	return h.insert.Close()
+}
+
+// Runs returns the task's recorded runs that started at or after since,
+// oldest first.
+func (h *SQLHistory) Runs(task string, since time.Time) ([]AuditRecord, error) {
+	rows, err := h.db.Query(`SELECT run, scheduled_at, started_at, ended_at, duration_ns, error
+	FROM every_runs WHERE task = ? AND started_at >= ? ORDER BY started_at`,
+		task, since.UTC().Format(sqliteTime))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var records []AuditRecord
+	for rows.Next() {
+		var (
+			run                int64
+			scheduled, failure sql.NullString
+			started, ended     string
+			duration           int64
+		)
+		if err := rows.Scan(&run, &scheduled, &started, &ended, &duration, &failure); err != nil {
+			return nil, err
+		}
+		r := AuditRecord{Task: task, Run: uint64(run), Duration: time.Duration(duration), Error: failure.String}
+		r.Scheduled, _ = time.Parse(sqliteTime, scheduled.String)
+		if r.Start, err = time.Parse(sqliteTime, started); err != nil {
+			return nil, err
+		}
+		if r.End, err = time.Parse(sqliteTime, ended); err != nil {
+			return nil, err
+		}
+		records = append(records, r)
+	}
+	return records, rows.Err()
+}
@@ -0,0 +1,141 @@
+package every
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// runsDriver is a database/sql driver holding every_runs in memory,
+// understanding only the statements SQLHistory sends.
+type runsDriver struct {
+	mu   sync.Mutex
+	rows [][]driver.Value
+}
+
+func (d *runsDriver) Open(string) (driver.Conn, error)             { return runsConn{d}, nil }
+func (d *runsDriver) Connect(context.Context) (driver.Conn, error) { return runsConn{d}, nil }
+func (d *runsDriver) Driver() driver.Driver                        { return d }
+
+type runsConn struct{ d *runsDriver }
+
+func (c runsConn) Prepare(query string) (driver.Stmt, error) { return runsStmt{c.d, query}, nil }
+func (c runsConn) Close() error                              { return nil }
+func (c runsConn) Begin() (driver.Tx, error)                 { return nil, errors.New("no transactions") }
+
+type runsStmt struct {
+	d     *runsDriver
+	query string
+}
+
+func (s runsStmt) Close() error  { return nil }
+func (s runsStmt) NumInput() int { return -1 }
+
+func (s runsStmt) Exec(args []driver.Value) (driver.Result, error) {
+	if strings.HasPrefix(s.query, "INSERT") {
+		s.d.mu.Lock()
+		s.d.rows = append(s.d.rows, args)
+		s.d.mu.Unlock()
+	}
+	return driver.RowsAffected(1), nil
+}
+
+// Query answers the Runs query: the columns after task, for one task from
+// a start time on, by start time.
+func (s runsStmt) Query(args []driver.Value) (driver.Rows, error) {
+	s.d.mu.Lock()
+	defer s.d.mu.Unlock()
+
+	var rows [][]driver.Value
+	for _, row := range s.d.rows {
+		if row[0] == args[0] && row[3].(string) >= args[1].(string) {
+			rows = append(rows, row[1:])
+		}
+	}
+	slices.SortStableFunc(rows, func(a, b []driver.Value) int { return strings.Compare(a[2].(string), b[2].(string)) })
+	return &runsRows{rows: rows}, nil
+}
+
+type runsRows struct{ rows [][]driver.Value }
+
+func (r *runsRows) Columns() []string {
+	return []string{"run", "scheduled_at", "started_at", "ended_at", "duration_ns", "error"}
+}
+func (r *runsRows) Close() error { return nil }
+
+func (r *runsRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+	return nil
+}
+
+func TestSQLHistory(t *testing.T) {
+	db := sql.OpenDB(&runsDriver{})
+	defer db.Close()
+	h, err := NewSQLHistory(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer h.Close()
+
+	day := time.Date(2024, 5, 1, 2, 0, 0, 0, time.UTC)
+	record := func(task string, run uint64, start time.Time, scheduled bool, failure string) AuditRecord {
+		r := AuditRecord{Task: task, Run: run, Start: start, End: start.Add(90 * time.Second), Duration: 90 * time.Second, Error: failure}
+		if scheduled {
+			r.Scheduled = start.Add(-time.Second)
+		}
+		return r
+	}
+	records := []AuditRecord{
+		record("report", 2, day.AddDate(0, 0, 1), true, "timeout"),
+		record("report", 1, day, true, ""),
+		record("cleanup", 1, day.Add(time.Hour), false, ""),
+		// Stored in UTC, whatever the zone it was recorded in.
+		record("report", 3, day.AddDate(0, 0, 2).In(time.FixedZone("CEST", 2*60*60)), false, ""),
+	}
+	for _, r := range records {
+		if err := h.Write(r); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, tc := range []struct {
+		name  string
+		task  string
+		since time.Time
+		want  []AuditRecord
+	}{
+		{"all", "report", time.Time{}, []AuditRecord{records[1], records[0], records[3]}},
+		{"since", "report", day.Add(time.Hour), []AuditRecord{records[0], records[3]}},
+		{"at start", "report", day.AddDate(0, 0, 2), []AuditRecord{records[3]}},
+		{"other task", "cleanup", time.Time{}, []AuditRecord{records[2]}},
+		{"none", "missing", time.Time{}, nil},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := h.Runs(tc.task, tc.since)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != len(tc.want) {
+				t.Fatalf("Runs() = %v, want %v", got, tc.want)
+			}
+			for i, want := range tc.want {
+				g := got[i]
+				if g.Task != want.Task || g.Run != want.Run || !g.Scheduled.Equal(want.Scheduled) ||
+					!g.Start.Equal(want.Start) || !g.End.Equal(want.End) || g.Duration != want.Duration || g.Error != want.Error {
+					t.Errorf("run %d: %+v, want %+v", i, g, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,24 @@
+package every
+
+import "net/http"
+
+// ListenAndServe starts every task and then serves srv until it is shut
+// down or fails. It stops the tasks before returning, so by the time
+// ListenAndServe returns after srv.Shutdown no run is still in progress.
+// The error is that of srv.ListenAndServe, http.ErrServerClosed after a
+// shutdown.
+func (s *Scheduler) ListenAndServe(srv *http.Server) error {
+	s.StartAll()
+	err := srv.ListenAndServe()
+	s.StopAll()
+	return err
+}
+
+// ListenAndServeTLS is like ListenAndServe, but serves HTTPS using
+// srv.ListenAndServeTLS.
+func (s *Scheduler) ListenAndServeTLS(srv *http.Server, certFile, keyFile string) error {
+	s.StartAll()
+	err := srv.ListenAndServeTLS(certFile, keyFile)
+	s.StopAll()
+	return err
+}
@@ -0,0 +1,155 @@
+package every
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"log/slog"
+	"math"
+	"os"
+	"strings"
+	"time"
+)
+
+// Interval is a duration written in the package's interval grammar, such
+// as "1h30m", that formats back the same way.
+type Interval time.Duration
+
+// ParseInterval parses s in the interval grammar. It also takes the
+// time.ParseDuration form, such as "1.5s", in which String writes
+// intervals with a fraction of a second. Like a task's interval, it must
+// be at least a second.
+func ParseInterval(s string) (Interval, error) {
+	i, err := readInterval(s)
+	if err != nil {
+		return 0, err
+	}
+	if i < Interval(defaultMinInterval) {
+		return 0, fmt.Errorf("%w: interval %s is below the minimum of %s", ErrInvalidValue, s, formatDuration(defaultMinInterval))
+	}
+	return i, nil
+}
+
+// readInterval is ParseInterval without the minimum, so that every
+// Interval, including ones such as 0 or 250ms that String writes in
+// Go's syntax, reads back as itself.
+func readInterval(s string) (Interval, error) {
+	d, err := parseDuration(s)
+	if err != nil {
+		if d, perr := time.ParseDuration(strings.TrimSpace(s)); perr == nil {
+			return Interval(d), nil
+		}
+		return 0, err
+	}
+	return Interval(d), nil
+}
+
+// IntervalFromEnv parses the environment variable name as an Interval,
+// falling back to def when it is unset or empty, and logs the value used
+// with the default slog logger. An invalid value is an error rather than a
+// silent fallback, so typos do not go unnoticed.
+func IntervalFromEnv(name, def string) (Interval, error) {
+	s, source := os.Getenv(name), "env"
+	if s == "" {
+		s, source = def, "default"
+	}
+	i, err := ParseInterval(s)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", name, err)
+	}
+	slog.Info("interval from environment", "name", name, "interval", i, "source", source)
+	return i, nil
+}
+
+func (i Interval) Duration() time.Duration {
+	return time.Duration(i)
+}
+
+func (i Interval) String() string {
+	return formatDuration(time.Duration(i))
+}
+
+// Set parses s into i, so an *Interval can be used as a flag.Value, as in
+// flag.Var(&every, "sync-every", "how often to sync").
+func (i *Interval) Set(s string) error {
+	v, err := ParseInterval(s)
+	if err != nil {
+		return err
+	}
+	*i = v
+	return nil
+}
+
+// Type names the flag type for pflag.
+func (i *Interval) Type() string {
+	return "interval"
+}
+
+// MarshalText formats i in the interval grammar, so config structs holding
+// an Interval encode it as a string such as "1h30m".
+func (i Interval) MarshalText() ([]byte, error) {
+	return []byte(i.String()), nil
+}
+
+// UnmarshalText reads back any Interval MarshalText wrote. Unlike Set, it
+// does not hold the interval to a minimum.
+func (i *Interval) UnmarshalText(text []byte) error {
+	v, err := readInterval(string(text))
+	if err != nil {
+		return err
+	}
+	*i = v
+	return nil
+}
+
+// Scan reads i from a database column holding an interval string, or an
+// integer count of nanoseconds. NULL scans as zero.
+func (i *Interval) Scan(src any) error {
+	switch src := src.(type) {
+	case nil:
+		*i = 0
+		return nil
+	case string:
+		return i.UnmarshalText([]byte(src))
+	case []byte:
+		return i.UnmarshalText(src)
+	case int64:
+		*i = Interval(src)
+		return nil
+	}
+	return fmt.Errorf("%w: cannot scan %T into Interval", ErrInvalidValue, src)
+}
+
+// Value stores i in the database as an interval string.
+func (i Interval) Value() (driver.Value, error) {
+	return i.String(), nil
+}
+
+// Add returns i+j, saturating at the largest Interval rather than
+// overflowing.
+func (i Interval) Add(j Interval) Interval {
+	if j > 0 && i > math.MaxInt64-j {
+		return math.MaxInt64
+	}
+	return i + j
+}
+
+// Scale returns i multiplied by f, rounded to the nearest nanosecond and
+// saturating like Add. f must not be negative.
+func (i Interval) Scale(f float64) Interval {
+	scaled := math.Round(float64(i) * f)
+	if scaled >= math.MaxInt64 {
+		return math.MaxInt64
+	}
+	return Interval(scaled)
+}
+
+// Truncate rounds i down to a multiple of m, such as a whole minute.
+func (i Interval) Truncate(m Interval) Interval {
+	return Interval(time.Duration(i).Truncate(time.Duration(m)))
+}
+
+// Until returns how long from now until an interval task that last fired
+// at last fires again, or a negative duration if that is overdue.
+func (i Interval) Until(last time.Time) time.Duration {
+	return time.Until(last.Add(time.Duration(i)))
+}
@@ -0,0 +1,56 @@
+package every
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestIntervalRoundTrip(t *testing.T) {
+	for _, i := range []Interval{
+		0,
+		Interval(90 * time.Minute),
+		Interval(1500 * time.Millisecond),
+		Interval(-time.Second),
+		Interval(time.Hour).Scale(1.0001),
+		Interval(90 * time.Second).Truncate(Interval(time.Minute)),
+		Interval(time.Nanosecond),
+	} {
+		text, err := i.MarshalText()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got Interval
+		if err := got.UnmarshalText(text); err != nil || got != i {
+			t.Errorf("%v: text %q reads back as %v, %v", time.Duration(i), text, time.Duration(got), err)
+		}
+
+		v, _ := i.Value()
+		got = 0
+		if err := got.Scan(v); err != nil || got != i {
+			t.Errorf("%v: column %q scans back as %v, %v", time.Duration(i), v, time.Duration(got), err)
+		}
+	}
+}
+
+func TestParseIntervalErrors(t *testing.T) {
+	for _, tc := range []struct {
+		in  string
+		err error
+	}{
+		{"5w", ErrInvalidUnit},
+		{"5", ErrInvalidUnit},
+		{"1.5x", ErrInvalidUnit},
+		{"0", ErrInvalidValue},
+		{"0s", ErrInvalidValue},
+		{"-1s", ErrInvalidValue},
+		{"250ms", ErrInvalidValue},
+	} {
+		if _, err := ParseInterval(tc.in); !errors.Is(err, tc.err) {
+			t.Errorf("ParseInterval(%q) = %v, want %v", tc.in, err, tc.err)
+		}
+	}
+	if i, err := ParseInterval("1.5s"); err != nil || i != Interval(1500*time.Millisecond) {
+		t.Errorf("ParseInterval(\"1.5s\") = %v, %v", i, err)
+	}
+}
@@ -0,0 +1,42 @@
+package every
+
+import (
+	"iter"
+	"slices"
+)
+
+func WithTags(tags ...string) Option {
+	return func(t *Task) {
+		t.tags = append(t.tags, tags...)
+	}
+}
+
+func (t *Task) Tags() []string {
+	return slices.Clone(t.tags)
+}
+
+func (t *Task) HasTag(tag string) bool {
+	return slices.Contains(t.tags, tag)
+}
+
+// All iterates over the registered tasks in registration order. Tasks
+// added during iteration are not visited.
+func (s *Scheduler) All() iter.Seq[*Task] {
+	return func(yield func(*Task) bool) {
+		for _, t := range s.Tasks() {
+			if !yield(t) {
+				return
+			}
+		}
+	}
+}
+
+func (s *Scheduler) FilterByTag(tag string) iter.Seq[*Task] {
+	return func(yield func(*Task) bool) {
+		for t := range s.All() {
+			if t.HasTag(tag) && !yield(t) {
+				return
+			}
+		}
+	}
+}
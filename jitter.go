@@ -0,0 +1,95 @@
+package every
+
+import (
+	"math/rand/v2"
+	"time"
+)
+
+// Jitter randomises the delay between runs of an interval task, so a fleet
+// started together drifts apart. The strategies follow the AWS
+// Architecture Blog's "Exponential Backoff And Jitter". Each takes an
+// optional *rand.Rand so tests and simulations can seed it; since a
+// rand.Rand is not safe for concurrent use, give every task its own.
+type Jitter interface {
+	// Apply returns the delay to use in place of d. prev is the delay it
+	// returned last time, or zero the first time.
+	Apply(d, prev time.Duration) time.Duration
+}
+
+// FullJitter waits anywhere between zero and the full interval.
+type FullJitter struct {
+	// Rand is the source of randomness, or nil for the global one.
+	Rand *rand.Rand
+}
+
+func (j FullJitter) Apply(d, _ time.Duration) time.Duration {
+	if d <= 0 {
+		return d
+	}
+	return randN(j.Rand, d+1)
+}
+
+// EqualJitter keeps half the interval and randomises the other half.
+type EqualJitter struct {
+	// Rand is the source of randomness, or nil for the global one.
+	Rand *rand.Rand
+}
+
+func (j EqualJitter) Apply(d, _ time.Duration) time.Duration {
+	if d <= 0 {
+		return d
+	}
+	return d/2 + randN(j.Rand, d-d/2+1)
+}
+
+// DecorrelatedJitter waits between the interval and three times the
+// previous delay, capped at Max if it is set.
+type DecorrelatedJitter struct {
+	Max time.Duration
+	// Rand is the source of randomness, or nil for the global one.
+	Rand *rand.Rand
+}
+
+func (j DecorrelatedJitter) Apply(d, prev time.Duration) time.Duration {
+	if d <= 0 {
+		return d
+	}
+	upper := max(3*prev, d)
+	delay := d + randN(j.Rand, upper-d+1)
+	if j.Max > 0 {
+		delay = min(delay, j.Max)
+	}
+	return delay
+}
+
+// randN returns a random duration in [0, n) from r, or from the global
+// source if r is nil.
+func randN(r *rand.Rand, n time.Duration) time.Duration {
+	if r == nil {
+		return rand.N(n)
+	}
+	return time.Duration(r.Int64N(int64(n)))
+}
+
+// WithJitter applies j to every wait between runs of an interval task.
+// Calendar schedules keep their fire times. Previews and NextN show the
+// fire times without jitter.
+func WithJitter(j Jitter) Option {
+	return func(t *Task) {
+		t.jitter = j
+	}
+}
+
+// jittered applies the task's jitter to the wait from from until next.
+func (t *Task) jittered(from, next time.Time) time.Time {
+	if t.jitter == nil || next.IsZero() {
+		return next
+	}
+	if _, ok := t.schedule.(intervalSchedule); !ok {
+		return next
+	}
+
+	t.lastJitter = t.jitter.Apply(next.Sub(from), t.lastJitter)
+	t.jitterShift = t.lastJitter - next.Sub(from)
+	return from.Add(t.lastJitter)
+}
@@ -0,0 +1,260 @@
+package every
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// The MarshalJSON methods below give the package's reporting types a
+// stable JSON form to serve as is: timestamps are RFC 3339 in UTC, null or
+// left out when unset, and durations are strings in the interval grammar
+// such as "1h30m", in Go's syntax when they have a fraction of a second.
+// The UnmarshalJSON methods read that form back.
+
+func timestamp(t time.Time) *string {
+	if t.IsZero() {
+		return nil
+	}
+	s := t.UTC().Format(time.RFC3339Nano)
+	return &s
+}
+
+// parseTimestamp reads back a timestamp, with null as the zero time.
+func parseTimestamp(s *string) (time.Time, error) {
+	if s == nil {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339Nano, *s)
+}
+
+// parseFormatted reads back a duration written by formatDuration, with
+// the empty string as zero.
+func parseFormatted(s string) (time.Duration, error) {
+	if s == "" {
+		return 0, nil
+	}
+	if d, err := parseDuration(s); err == nil {
+		return d, nil
+	}
+	return time.ParseDuration(s)
+}
+
+// timestamps parses each of src into the matching dst, stopping at the
+// first error.
+func timestamps(dst []*time.Time, src ...*string) error {
+	for i, s := range src {
+		t, err := parseTimestamp(s)
+		if err != nil {
+			return err
+		}
+		*dst[i] = t
+	}
+	return nil
+}
+
+// durations is timestamps for durations.
+func durations(dst []*time.Duration, src ...string) error {
+	for i, s := range src {
+		d, err := parseFormatted(s)
+		if err != nil {
+			return err
+		}
+		*dst[i] = d
+	}
+	return nil
+}
+
+type statsJSON struct {
+	Runs          int64  `json:"runs"`
+	Skips         int64  `json:"skips"`
+	Errors        int64  `json:"errors"`
+	Gaps          int64  `json:"gaps"`
+	LastError     string `json:"last_error,omitempty"`
+	TotalDuration string `json:"total_duration"`
+	LastDuration  string `json:"last_duration"`
+	P50           string `json:"p50"`
+	P95           string `json:"p95"`
+	P99           string `json:"p99"`
+}
+
+func (s Stats) MarshalJSON() ([]byte, error) {
+	return json.Marshal(statsJSON{
+		s.Runs, s.Skips, s.Errors, s.Gaps, s.LastError,
+		formatDuration(s.TotalDuration), formatDuration(s.LastDuration),
+		formatDuration(s.P50), formatDuration(s.P95), formatDuration(s.P99),
+	})
+}
+
+func (s *Stats) UnmarshalJSON(data []byte) error {
+	var j statsJSON
+	if err := json.Unmarshal(data, &j); err != nil {
+		return err
+	}
+	*s = Stats{Runs: j.Runs, Skips: j.Skips, Errors: j.Errors, Gaps: j.Gaps, LastError: j.LastError}
+	return durations([]*time.Duration{&s.TotalDuration, &s.LastDuration, &s.P50, &s.P95, &s.P99},
+		j.TotalDuration, j.LastDuration, j.P50, j.P95, j.P99)
+}
+
+type progressJSON struct {
+	Done    int64   `json:"done"`
+	Total   int64   `json:"total"`
+	Message string  `json:"message"`
+	Updated *string `json:"updated"`
+}
+
+func (p Progress) MarshalJSON() ([]byte, error) {
+	return json.Marshal(progressJSON{p.Done, p.Total, p.Message, timestamp(p.Updated)})
+}
+
+func (p *Progress) UnmarshalJSON(data []byte) error {
+	var j progressJSON
+	if err := json.Unmarshal(data, &j); err != nil {
+		return err
+	}
+	*p = Progress{Done: j.Done, Total: j.Total, Message: j.Message}
+	return timestamps([]*time.Time{&p.Updated}, j.Updated)
+}
+
+type taskSnapshotJSON struct {
+	ID       TaskID     `json:"id"`
+	Name     string     `json:"name"`
+	Tags     []string   `json:"tags,omitempty"`
+	Schedule string     `json:"schedule"`
+	State    State      `json:"state"`
+	Paused   bool       `json:"paused,omitempty"`
+	NextRun  *string    `json:"next_run"`
+	LastRun  *string    `json:"last_run"`
+	Stats    Stats      `json:"stats"`
+	Progress Progress   `json:"progress"`
+	Trace    []Decision `json:"trace,omitempty"`
+}
+
+func (s TaskSnapshot) MarshalJSON() ([]byte, error) {
+	return json.Marshal(taskSnapshotJSON{
+		s.ID, s.Name, s.Tags, s.Schedule, s.State, s.Paused,
+		timestamp(s.NextRun), timestamp(s.LastRun), s.Stats, s.Progress, s.Trace,
+	})
+}
+
+func (s *TaskSnapshot) UnmarshalJSON(data []byte) error {
+	var j taskSnapshotJSON
+	if err := json.Unmarshal(data, &j); err != nil {
+		return err
+	}
+	*s = TaskSnapshot{
+		ID: j.ID, Name: j.Name, Tags: j.Tags, Schedule: j.Schedule, State: j.State, Paused: j.Paused,
+		Stats: j.Stats, Progress: j.Progress, Trace: j.Trace,
+	}
+	return timestamps([]*time.Time{&s.NextRun, &s.LastRun}, j.NextRun, j.LastRun)
+}
+
+type decisionJSON struct {
+	Time      *string      `json:"time"`
+	Kind      DecisionKind `json:"kind"`
+	Next      *string      `json:"next,omitempty"`
+	Jitter    string       `json:"jitter,omitempty"`
+	Scheduled *string      `json:"scheduled,omitempty"`
+	Late      string       `json:"late,omitempty"`
+	Waited    string       `json:"waited,omitempty"`
+	Reason    string       `json:"reason,omitempty"`
+	Count     int          `json:"count,omitempty"`
+	Queued    int          `json:"queued,omitempty"`
+}
+
+func (d Decision) MarshalJSON() ([]byte, error) {
+	var jitter, late, waited string
+	if d.Jitter != 0 {
+		jitter = formatDuration(d.Jitter)
+	}
+	if d.Late != 0 {
+		late = formatDuration(d.Late)
+	}
+	if d.Waited != 0 {
+		waited = formatDuration(d.Waited)
+	}
+	return json.Marshal(decisionJSON{
+		timestamp(d.Time), d.Kind, timestamp(d.Next), jitter,
+		timestamp(d.Scheduled), late, waited, d.Reason, d.Count, d.Queued,
+	})
+}
+
+func (d *Decision) UnmarshalJSON(data []byte) error {
+	var j decisionJSON
+	if err := json.Unmarshal(data, &j); err != nil {
+		return err
+	}
+	*d = Decision{Kind: j.Kind, Reason: j.Reason, Count: j.Count, Queued: j.Queued}
+	if err := timestamps([]*time.Time{&d.Time, &d.Next, &d.Scheduled}, j.Time, j.Next, j.Scheduled); err != nil {
+		return err
+	}
+	return durations([]*time.Duration{&d.Jitter, &d.Late, &d.Waited}, j.Jitter, j.Late, j.Waited)
+}
+
+type auditRecordJSON struct {
+	Task      string  `json:"task"`
+	Run       uint64  `json:"run"`
+	Scheduled *string `json:"scheduled"`
+	Start     *string `json:"start"`
+	End       *string `json:"end"`
+	Duration  string  `json:"duration"`
+	Error     string  `json:"error,omitempty"`
+}
+
+func (r AuditRecord) MarshalJSON() ([]byte, error) {
+	return json.Marshal(auditRecordJSON{
+		r.Task, r.Run, timestamp(r.Scheduled), timestamp(r.Start), timestamp(r.End),
+		formatDuration(r.Duration), r.Error,
+	})
+}
+
+func (r *AuditRecord) UnmarshalJSON(data []byte) error {
+	var j auditRecordJSON
+	if err := json.Unmarshal(data, &j); err != nil {
+		return err
+	}
+	*r = AuditRecord{Task: j.Task, Run: j.Run, Error: j.Error}
+	if err := timestamps([]*time.Time{&r.Scheduled, &r.Start, &r.End}, j.Scheduled, j.Start, j.End); err != nil {
+		return err
+	}
+	return durations([]*time.Duration{&r.Duration}, j.Duration)
+}
+
+type fireInfoJSON struct {
+	Scheduled *string `json:"scheduled"`
+	Actual    *string `json:"actual"`
+	Previous  *string `json:"previous"`
+	CatchUp   bool    `json:"catch_up,omitempty"`
+}
+
+func (f FireInfo) MarshalJSON() ([]byte, error) {
+	return json.Marshal(fireInfoJSON{timestamp(f.Scheduled), timestamp(f.Actual), timestamp(f.Previous), f.CatchUp})
+}
+
+func (f *FireInfo) UnmarshalJSON(data []byte) error {
+	var j fireInfoJSON
+	if err := json.Unmarshal(data, &j); err != nil {
+		return err
+	}
+	*f = FireInfo{CatchUp: j.CatchUp}
+	return timestamps([]*time.Time{&f.Scheduled, &f.Actual, &f.Previous}, j.Scheduled, j.Actual, j.Previous)
+}
+
+type taskLoadJSON struct {
+	Name   string `json:"name"`
+	Runs   int64  `json:"runs"`
+	Errors int64  `json:"errors"`
+	Total  string `json:"total"`
+}
+
+func (l TaskLoad) MarshalJSON() ([]byte, error) {
+	return json.Marshal(taskLoadJSON{l.Name, l.Runs, l.Errors, formatDuration(l.Total)})
+}
+
+func (l *TaskLoad) UnmarshalJSON(data []byte) error {
+	var j taskLoadJSON
+	if err := json.Unmarshal(data, &j); err != nil {
+		return err
+	}
+	*l = TaskLoad{Name: j.Name, Runs: j.Runs, Errors: j.Errors}
+	return durations([]*time.Duration{&l.Total}, j.Total)
+}
@@ -0,0 +1,44 @@
+package every
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestJSONRoundTrip(t *testing.T) {
+	at := time.Date(2026, 3, 1, 9, 30, 0, 500, time.UTC)
+	stats := Stats{Runs: 3, Skips: 1, Errors: 1, LastError: "boom", TotalDuration: 90 * time.Second, LastDuration: 1500 * time.Millisecond, P50: time.Second, P95: 2 * time.Second, P99: time.Minute}
+	for _, tc := range []struct {
+		name string
+		v    any
+	}{
+		{"Stats", &stats},
+		{"Stats zero", &Stats{}},
+		{"Progress", &Progress{Done: 5, Total: 10, Message: "halfway", Updated: at}},
+		{"TaskSnapshot", &TaskSnapshot{
+			ID: 7, Name: "sync", Tags: []string{"io"}, Schedule: "1h", State: StateWaiting, Paused: true,
+			NextRun: at.Add(time.Hour), Stats: stats, Progress: Progress{Done: 1},
+			Trace: []Decision{{Time: at, Kind: DecisionSkip, Reason: "paused", Count: 1}},
+		}},
+		{"Decision", &Decision{Time: at, Kind: DecisionFire, Scheduled: at, Late: 3 * time.Millisecond, Waited: time.Second, Queued: 2}},
+		{"AuditRecord", &AuditRecord{Task: "sync", Run: 4, Scheduled: at, Start: at, End: at.Add(time.Second), Duration: time.Second, Error: "boom"}},
+		{"FireInfo", &FireInfo{Scheduled: at, Actual: at.Add(time.Millisecond), CatchUp: true}},
+		{"TaskLoad", &TaskLoad{Name: "sync", Runs: 2, Errors: 1, Total: 36 * time.Hour}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			data, err := json.Marshal(tc.v)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := reflect.New(reflect.TypeOf(tc.v).Elem()).Interface()
+			if err := json.Unmarshal(data, got); err != nil {
+				t.Fatalf("Unmarshal(%s): %v", data, err)
+			}
+			if !reflect.DeepEqual(got, tc.v) {
+				t.Errorf("%s read back as %+v, want %+v", data, got, tc.v)
+			}
+		})
+	}
+}
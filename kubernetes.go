@@ -0,0 +1,244 @@
+package every
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cronJobNameAnnotation holds the task name on exported CronJobs, since
+// the manifest's own name must be a DNS label.
+const cronJobNameAnnotation = "every/task"
+
+// maxCronJobName is the longest name Kubernetes accepts for a CronJob.
+const maxCronJobName = 52
+
+// ExportCronJobs writes every task as a Kubernetes batch/v1 CronJob
+// manifest to w, separated by "---". Each job runs image with the task
+// name as its only argument. Calendar schedules follow the local time
+// zone, so their CronJobs get it as spec.timeZone; if its IANA name cannot
+// be found, a comment says so, and the CronJob runs in the controller's
+// zone. Names that clash once made valid get a numeric suffix. Schedules
+// cron cannot express are written as comments, as with ExportCrontab.
+func (s *Scheduler) ExportCronJobs(w io.Writer, image string) error {
+	bw := bufio.NewWriter(w)
+	written := false
+	names := make(map[string]bool)
+	zone, zoneKnown := localZone()
+	for _, t := range s.Tasks() {
+		sched := t.currentSchedule()
+		crontab, ok := crontabSpec(sched)
+		if !ok {
+			fmt.Fprintf(bw, "# every %s %s\n", t.Schedule(), t.name)
+			continue
+		}
+		if written {
+			bw.WriteString("---\n")
+		}
+		written = true
+		name := uniqueName(cronJobName(t), names)
+		timeZone := ""
+		if _, interval := sched.(intervalSchedule); !interval {
+			if zoneKnown {
+				timeZone = "\n  timeZone: " + strconv.Quote(zone)
+			} else {
+				fmt.Fprintf(bw, "# %s: local time zone unknown, so the CronJob runs in the controller's\n", t.name)
+			}
+		}
+		fmt.Fprintf(bw, `apiVersion: batch/v1
+kind: CronJob
+metadata:
+  name: %s
+  annotations:
+    %s: %s
+spec:
+  schedule: %s%s
+  concurrencyPolicy: Forbid
+  jobTemplate:
+    spec:
+      template:
+        spec:
+          restartPolicy: OnFailure
+          containers:
+          - name: %s
+            image: %s
+            args: [%s]
+`, name, cronJobNameAnnotation, strconv.Quote(t.name), strconv.Quote(crontab), timeZone, name, strconv.Quote(image), strconv.Quote(t.name))
+	}
+	return bw.Flush()
+}
+
+// cronJobName turns the task's name into a valid CronJob name.
+func cronJobName(t *Task) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(t.name) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			b.WriteRune(r)
+		case b.Len() > 0 && !strings.HasSuffix(b.String(), "-"):
+			b.WriteByte('-')
+		}
+	}
+	name := strings.Trim(b.String()[:min(b.Len(), maxCronJobName)], "-")
+	if name == "" {
+		name = fmt.Sprintf("task-%d", t.id)
+	}
+	return name
+}
+
+// uniqueName returns name, or name with the first free suffix such as
+// "-2" if it is already in taken, and adds the result to taken.
+func uniqueName(name string, taken map[string]bool) string {
+	unique := name
+	for n := 2; taken[unique]; n++ {
+		suffix := "-" + strconv.Itoa(n)
+		unique = strings.TrimRight(name[:min(len(name), maxCronJobName-len(suffix))], "-") + suffix
+	}
+	taken[unique] = true
+	return unique
+}
+
+// localZone returns the IANA name of the local time zone, which calendar
+// schedules follow, and whether it could be found.
+func localZone() (string, bool) {
+	if name := time.Local.String(); name != "Local" {
+		return name, true
+	}
+	if target, err := os.Readlink("/etc/localtime"); err == nil {
+		if _, name, ok := strings.Cut(target, "zoneinfo/"); ok {
+			return name, true
+		}
+	}
+	if data, err := os.ReadFile("/etc/timezone"); err == nil {
+		if name := strings.TrimSpace(string(data)); name != "" {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+// ImportCronJobs reads Kubernetes CronJob manifests from r and adds one task
+// per CronJob, using resolve to turn each task name into a task func. The
+// name comes from the annotation written by ExportCronJobs, falling back
+// to the manifest's name. Only the fields every needs are read, and only
+// in the block style kubectl and ExportCronJobs produce; other kinds of
+// object are skipped. Nothing is added unless every CronJob parses.
+func (s *Scheduler) ImportCronJobs(r io.Reader, resolve func(name string) (func(), error)) ([]*Task, error) {
+	docs, err := readManifests(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var tasks []*Task
+	for i, doc := range docs {
+		if doc["kind"] != "CronJob" {
+			continue
+		}
+		name := doc["metadata.annotations."+cronJobNameAnnotation]
+		if name == "" {
+			name = doc["metadata.name"]
+		}
+		if doc["spec.schedule"] == "" {
+			return nil, fmt.Errorf("manifest %d: missing schedule", i+1)
+		}
+		sched, err := parseSchedule(doc["spec.schedule"])
+		if err != nil {
+			return nil, fmt.Errorf("manifest %d: %w", i+1, err)
+		}
+		fn, err := resolve(name)
+		if err != nil {
+			return nil, fmt.Errorf("manifest %d: %w", i+1, err)
+		}
+
+		t := newTask(sched, func(context.Context) error {
+			fn()
+			return nil
+		})
+		t.name = name
+		tasks = append(tasks, t)
+	}
+
+	for _, t := range tasks {
+		s.Add(t)
+	}
+	return tasks, nil
+}
+
+// readManifests splits a YAML stream into documents, each flattened to a
+// map from dotted key paths to scalar values. Sequences are skipped.
+func readManifests(r io.Reader) ([]map[string]string, error) {
+	type key struct {
+		indent int
+		name   string
+	}
+	var (
+		docs  []map[string]string
+		doc   map[string]string
+		stack []key
+	)
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := scanner.Text()
+		if strings.HasPrefix(line, "---") {
+			doc, stack = nil, nil
+			continue
+		}
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || trimmed[0] == '#' {
+			continue
+		}
+		indent := len(line) - len(strings.TrimLeft(line, " "))
+		for len(stack) > 0 && stack[len(stack)-1].indent >= indent {
+			stack = stack[:len(stack)-1]
+		}
+		if trimmed[0] == '-' {
+			stack = append(stack, key{indent, "-"})
+			continue
+		}
+		name, value, ok := strings.Cut(trimmed, ":")
+		if !ok {
+			continue
+		}
+		stack = append(stack, key{indent, name})
+
+		value = strings.TrimSpace(value)
+		if value == "" || value[0] == '#' {
+			continue
+		}
+		path := make([]string, len(stack))
+		for i, k := range stack {
+			path[i] = k.name
+		}
+		v, err := yamlScalar(value)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", n, err)
+		}
+		if doc == nil {
+			doc = make(map[string]string)
+			docs = append(docs, doc)
+		}
+		doc[strings.Join(path, ".")] = v
+	}
+	return docs, scanner.Err()
+}
+
+func yamlScalar(s string) (string, error) {
+	switch s[0] {
+	case '"':
+		return strconv.Unquote(s)
+	case '\'':
+		if len(s) < 2 || s[len(s)-1] != '\'' {
+			return "", fmt.Errorf("unterminated string %s", s)
+		}
+		return strings.ReplaceAll(s[1:len(s)-1], "''", "'"), nil
+	}
+	if i := strings.Index(s, " #"); i >= 0 {
+		s = strings.TrimSpace(s[:i])
+	}
+	return s, nil
+}
@@ -0,0 +1,61 @@
+package every
+
+import (
+	"strings"
+	"testing"
+	"time"
+	_ "time/tzdata"
+)
+
+func TestExportCronJobs(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	local := time.Local
+	time.Local = ny
+	defer func() { time.Local = local }()
+
+	s := NewScheduler()
+	for _, task := range []struct{ name, spec string }{
+		{"Nightly Report", "daily@06:30"},
+		{"nightly_report", "0 7 * * *"},
+		{"poll", "5m"},
+	} {
+		tk, err := NewTask(task.spec, func() {}, WithName(task.name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		s.Add(tk)
+	}
+	var b strings.Builder
+	if err := s.ExportCronJobs(&b, "app:latest"); err != nil {
+		t.Fatal(err)
+	}
+
+	docs := strings.Split(b.String(), "---\n")
+	if len(docs) != 3 {
+		t.Fatalf("%d manifests, want 3:\n%s", len(docs), b.String())
+	}
+	for i, want := range []struct {
+		name string
+		zone bool
+	}{{"nightly-report", true}, {"nightly-report-2", true}, {"poll", false}} {
+		if !strings.Contains(docs[i], "\n  name: "+want.name+"\n") {
+			t.Errorf("manifest %d not named %s:\n%s", i, want.name, docs[i])
+		}
+		if got := strings.Contains(docs[i], `timeZone: "America/New_York"`); got != want.zone {
+			t.Errorf("manifest %d has time zone %v, want %v:\n%s", i, got, want.zone, docs[i])
+		}
+	}
+}
+
+func TestUniqueName(t *testing.T) {
+	taken := make(map[string]bool)
+	long := strings.Repeat("a", maxCronJobName)
+	for _, want := range []string{long, long[:maxCronJobName-2] + "-2", long[:maxCronJobName-2] + "-3"} {
+		if got := uniqueName(long, taken); got != want {
+			t.Errorf("uniqueName() = %q, want %q", got, want)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package every
+
+import (
+	"context"
+	"log/slog"
+)
+
+type discardHandler struct{}
+
+func (discardHandler) Enabled(context.Context, slog.Level) bool  { return false }
+func (discardHandler) Handle(context.Context, slog.Record) error { return nil }
+func (h discardHandler) WithAttrs([]slog.Attr) slog.Handler      { return h }
+func (h discardHandler) WithGroup(string) slog.Handler           { return h }
+
+var discardLogger = slog.New(discardHandler{})
+
+// WithLogger sets the logger for this task, overriding the one inherited
+// from its Scheduler, so noisy tasks can log at a different level.
+func WithLogger(l *slog.Logger) Option {
+	return func(t *Task) {
+		t.logger = l
+	}
+}
+
+// Logger returns the task's logger with the task name attached. Without a
+// task or Scheduler logger it discards everything.
+func (t *Task) Logger() *slog.Logger {
+	l := t.logger
+	if l == nil {
+		return discardLogger
+	}
+	if t.name != "" {
+		l = l.With("task", t.name)
+	}
+	return l
+}
+
+// SetLogger sets the logger used by tasks added afterwards that have no
+// logger of their own.
+func (s *Scheduler) SetLogger(l *slog.Logger) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.logger = l
+}
@@ -0,0 +1,331 @@
+package every
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// The handlers below make up a task's run loop. They are only ever called
+// one at a time: from the loop goroutine, or under loopMu when the task
+// runs on time.AfterFunc. Each leaves the timer armed for t.due.
+
+func (t *Task) begin() {
+	t.log = t.Logger()
+	t.debug = t.log.Enabled(t.ctx, slog.LevelDebug)
+	t.started = time.Now()
+
+	var dropped int
+	if t.events == nil {
+		t.due, dropped = t.first(t.started)
+		t.due = t.jittered(t.started, t.due)
+		// A one-shot that is already due still gets its run, subject to
+		// the misfire policy.
+		if s, ok := t.schedule.(onceSchedule); ok && t.runs == 0 && t.due.IsZero() {
+			t.due = s.at
+		}
+		if at, ok := t.recoverDelivery(); ok {
+			t.due, t.replaying = at, true
+		}
+	}
+	t.arm()
+	t.setState(StateWaiting, t.due)
+	t.traceNext()
+	t.log.Debug("task started", "next", t.due)
+	t.skip(SkipDayOff, dropped)
+}
+
+func (t *Task) end() {
+	if t.final && !t.finalDone {
+		t.finalDone = true
+		t.finalRun()
+	}
+	t.closeOutbox()
+	t.setState(StateStopped, time.Time{})
+	t.log.Debug("task stopped")
+}
+
+func (t *Task) reschedule(s schedule) {
+	t.mu.Lock()
+	old := t.schedule
+	t.schedule = s
+	t.mu.Unlock()
+	if t.events != nil {
+		return
+	}
+
+	var dropped int
+	now := time.Now()
+	if due, ok := t.rebased(old, now); ok {
+		t.due = due
+	} else {
+		t.due, dropped = t.nextCounted(now)
+		t.due = t.jittered(now, t.due)
+	}
+	t.arm()
+	t.setState(StateWaiting, t.due)
+	t.traceNext()
+	t.skip(SkipDayOff, dropped)
+}
+
+func (t *Task) triggered() {
+	if t.events == nil {
+		return
+	}
+
+	t.due = t.coolDown(t.events.trigger(time.Now(), t.due))
+	t.arm()
+	t.setState(StateWaiting, t.due)
+	t.traceNext()
+}
+
+func (t *Task) fire(now time.Time) {
+	t.checkClock(now)
+	if now.Before(t.due) {
+		t.arm()
+		return
+	}
+	at, drop, missed := t.misfired(t.schedule, now, t.due)
+	if missed {
+		t.log.Warn("task misfired", "scheduled", t.due, "policy", t.misfire)
+		if drop {
+			t.due = at
+			t.arm()
+			t.setState(StateWaiting, t.due)
+			t.skip(SkipMisfire, 1)
+			t.traceNext()
+			return
+		}
+	}
+	if t.taskFunc == nil {
+		t.noFunc(now)
+		return
+	}
+	if t.condition != nil && !t.condition() {
+		t.passOver(now, SkipCondition)
+		return
+	}
+	if t.breakerLimit > 0 && t.circuitOpen(now) {
+		t.passOver(now, SkipCircuitOpen)
+		return
+	}
+	if t.shedLoad != nil && t.overloaded() {
+		t.passOver(now, SkipOverload)
+		return
+	}
+	if t.paused.Load() || t.ns != nil && t.ns.paused.Load() {
+		t.passOver(now, SkipPaused)
+		return
+	}
+	if sb := t.standby.Load(); sb != nil && !sb.primary() {
+		t.passOver(now, SkipStandby)
+		return
+	}
+	if !t.lockFences() {
+		if t.ctx.Err() == nil {
+			t.passOver(now, SkipFenced)
+		}
+		return
+	}
+	if !t.ns.acquire(t.ctx) {
+		t.unlockFences()
+		return
+	}
+	if t.pool != nil && !t.pool.acquire(t.ctx, t) {
+		t.ns.release()
+		t.unlockFences()
+		return
+	}
+	if !t.admit() {
+		if t.pool != nil {
+			t.pool.release(t)
+		}
+		t.ns.release()
+		t.unlockFences()
+		return
+	}
+	// The fire is only claimed once nothing else can hold it back, so a
+	// fire dropped above is not left recorded as started.
+	if t.guaranteed() && !t.claim(t.due) {
+		t.inflight.Add(-1)
+		if t.pool != nil {
+			t.pool.release(t)
+		}
+		t.ns.release()
+		t.unlockFences()
+		t.replaying = false
+		t.passOver(now, SkipDelivered)
+		return
+	}
+
+	if t.async {
+		t.launch(now)
+		return
+	}
+
+	t.catchUp = missed && t.misfire == MisfireCatchUp
+	start := time.Now()
+	if t.traceSize > 0 {
+		t.decide(Decision{Kind: DecisionFire, Scheduled: t.due, Late: now.Sub(t.due), Waited: start.Sub(now)})
+	}
+	err := t.execute(start)
+	end := time.Now()
+	if t.guaranteed() {
+		t.delivered(t.due)
+		t.replaying = false
+	}
+	if t.audit != nil || t.historySink != nil {
+		t.record(t.runs, t.due, start, end, err)
+	}
+
+	var dropped int
+	if t.events != nil {
+		t.due = t.events.ran(start, end)
+	} else if next, ok := t.dequeue(end); ok {
+		t.due = next
+	} else if t.catchUp {
+		// Step through the missed fires rather than skipping to now;
+		// each one that is still past due misfires in turn.
+		t.due, dropped = t.following(t.due)
+	} else {
+		t.due, dropped = t.following(end)
+	}
+	if t.breakerLimit > 0 {
+		t.tripped(end, err)
+	}
+	if err != nil {
+		t.quietUntil = end.Add(t.cooldown)
+		t.due = t.coolDown(t.due)
+		t.log.Error("task failed", "error", err, "duration", end.Sub(start), "next", t.due, "run_id", t.current.info.RunID())
+	} else {
+		if t.debug {
+			t.log.Debug("task ran", "duration", end.Sub(start), "next", t.due)
+		}
+		if t.heartbeat != nil {
+			t.heartbeat()
+		}
+		t.succeedOnce.Do(func() { close(t.succeeded) })
+	}
+	if t.notifiers != nil && (err != nil || t.failing) {
+		t.failing = err != nil
+		e := Event{Type: EventRecovery, Time: start, Run: t.runs, Sequence: t.current.info.Sequence, Duration: end.Sub(start), Err: err}
+		if err != nil {
+			e.Type = failureEvent(err)
+		}
+		t.notify(e)
+	}
+	t.arm()
+	t.finish(end.Sub(start), t.due, err)
+	t.traceNext()
+	t.skip(SkipDayOff, dropped)
+	if t.disabling != nil {
+		t.disable(t.disabling)
+	}
+}
+
+// arm points the timer at t.due, or leaves it stopped when there is no
+// next fire.
+func (t *Task) arm() {
+	t.timer.Stop()
+	if !t.due.IsZero() && !t.disabled {
+		t.armed = time.Now()
+		t.armedWall = t.armed.Round(0)
+		t.timer.Reset(t.wait(t.due))
+	}
+}
+
+// wait returns how long the run loop may sleep before looking at next
+// again. Calendar schedules compare against the wall clock, so their
+// sleeps are capped at wakeCheck to notice suspends and clock steps.
+func (t *Task) wait(next time.Time) time.Duration {
+	d := time.Until(t.coalesced(next))
+	if _, ok := t.schedule.(intervalSchedule); !ok && d > wakeCheck {
+		return wakeCheck
+	}
+	return d
+}
+
+// execute runs the task for t.due, giving back its pool and namespace
+// slots, fences and admission afterwards even if the run panics.
+func (t *Task) execute(start time.Time) error {
+	defer t.inflight.Add(-1)
+	defer t.unlockFences()
+	defer t.ns.release()
+	ctx := t.runCtx
+	if t.pool != nil {
+		var cancel context.CancelCauseFunc
+		ctx, cancel = context.WithCancelCause(ctx)
+		t.pool.started(t, cancel)
+		defer cancel(nil)
+		defer t.pool.release(t)
+	}
+
+	if err := t.loadBag(); err != nil {
+		return err
+	}
+
+	err := t.run(ctx, t.due, start)
+	if context.Cause(ctx) == ErrPreempted {
+		err = ErrPreempted
+	}
+	for attempt := 1; err != nil && err != ErrPreempted && attempt <= t.retries; attempt++ {
+		delay := t.backoff.NextDelay(attempt)
+		t.log.Warn("task failed, retrying", "error", err, "attempt", attempt, "retry", delay)
+		if !t.sleep(delay) {
+			break
+		}
+		t.current.info.Attempt = attempt + 1
+		t.current.info.Fired = time.Now()
+		t.progress.Store(nil)
+		err = t.call(ctx)
+	}
+	// Subscribers hear once per fire, after the last attempt.
+	t.subs.publish(ctx)
+	if saveErr := t.saveBag(); err == nil {
+		err = saveErr
+	}
+	return err
+}
+
+func (t *Task) run(ctx context.Context, scheduled, start time.Time) error {
+	t.state.Store(int32(StateRunning))
+	t.lastRun.Store(start)
+	t.nextRun.Store(time.Time{})
+	t.progress.Store(nil)
+
+	t.runs++
+	t.current.info = RunInfo{ID: t.runs, Attempt: 1, Scheduled: scheduled, Fired: start, Previous: t.lastScheduled, CatchUp: t.catchUp, seq: runSeq.Add(1)}
+	t.lastScheduled = scheduled
+	t.sequence(&t.current.info)
+	return t.call(ctx)
+}
+
+func (t *Task) call(ctx context.Context) (err error) {
+	if t.panicLimit > 0 {
+		defer func() {
+			if r := recover(); r != nil {
+				err = t.panicked(r)
+			}
+		}()
+	}
+	if t.profileLabels {
+		t.labelled(ctx, func(ctx context.Context) { err = t.invoke(ctx) })
+		return err
+	}
+	return t.invoke(ctx)
+}
+
+func (t *Task) invoke(ctx context.Context) error {
+	if t.timeout > 0 {
+		return t.runTimeout(ctx)
+	}
+	return t.taskFunc(ctx)
+}
+
+func (t *Task) finish(d time.Duration, next time.Time, err error) {
+	t.stats.ran(d, err)
+	t.setState(StateWaiting, next)
+	if t.spent(next) {
+		t.retire()
+	}
+}
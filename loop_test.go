@@ -0,0 +1,107 @@
+package every
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+// firing returns a task set up as its run loop would be, so that fire can
+// be driven directly.
+func firing(tb testing.TB, opts ...Option) *Task {
+	tb.Helper()
+	t, err := NewTask("1s", func() {}, opts...)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	t.timer = timers.get()
+	t.begin()
+	tb.Cleanup(func() {
+		timers.put(t.timer)
+		t.closeOutbox()
+		t.cancel()
+	})
+	return t
+}
+
+func TestFireDoesNotAllocate(t *testing.T) {
+	task := firing(t)
+	allocs := testing.AllocsPerRun(1000, func() {
+		now := time.Now()
+		task.due = now
+		task.fire(now)
+	})
+	if allocs != 0 {
+		t.Errorf("fire allocates %v times per run, want 0", allocs)
+	}
+}
+
+func BenchmarkFire(b *testing.B) {
+	task := firing(b)
+	b.ReportAllocs()
+	for range b.N {
+		now := time.Now()
+		task.due = now
+		task.fire(now)
+	}
+}
+
+// BenchmarkFireContended measures the fire path while other goroutines
+// keep reading the task's state, stats and schedule, against the same path
+// left alone. Allocations are not reported, as the readers' would count.
+func BenchmarkFireContended(b *testing.B) {
+	for _, readers := range []int{0, 1, 4} {
+		b.Run(fmt.Sprintf("readers=%d", readers), func(b *testing.B) {
+			task := firing(b)
+			stop := make(chan struct{})
+			var wg sync.WaitGroup
+			for range readers {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					for {
+						select {
+						case <-stop:
+							return
+						default:
+						}
+						_ = task.State()
+						_ = task.Stats()
+						_ = task.Schedule()
+					}
+				}()
+			}
+
+			b.ResetTimer()
+			for range b.N {
+				now := time.Now()
+				task.due = now
+				task.fire(now)
+			}
+			b.StopTimer()
+			close(stop)
+			wg.Wait()
+		})
+	}
+}
+
+func BenchmarkTimerPool(b *testing.B) {
+	b.ReportAllocs()
+	for range b.N {
+		t := timers.get()
+		t.Reset(time.Hour)
+		timers.put(t)
+	}
+}
+
+func BenchmarkTimerPoolParallel(b *testing.B) {
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			t := timers.get()
+			t.Reset(time.Hour)
+			timers.put(t)
+		}
+	})
+}
@@ -0,0 +1,11 @@
+package every_test
+
+import (
+	"testing"
+
+	"github.com/daifiyum/every/everytest"
+)
+
+func TestMain(m *testing.M) {
+	everytest.VerifyTestMain(m)
+}
@@ -0,0 +1,86 @@
+package every
+
+import (
+	"fmt"
+	"time"
+)
+
+// misfireThreshold is how late a calendar fire may be before it counts as
+// missed, matching Quartz's default.
+const misfireThreshold = time.Minute
+
+// MisfirePolicy decides what a calendar task does when it wakes up to find
+// a fire time has already passed, typically after a suspend or clock step.
+type MisfirePolicy int
+
+const (
+	// MisfireFireNow runs the missed execution immediately, once, however
+	// many fire times were missed.
+	MisfireFireNow MisfirePolicy = iota
+	// MisfireDoNothing drops the missed execution and waits for the next
+	// fire time after now.
+	MisfireDoNothing
+	// MisfireRescheduleNext drops the missed execution and waits one full
+	// schedule period from now before firing, shifting that one run to
+	// when the task woke up.
+	MisfireRescheduleNext
+	// MisfireCatchUp runs every missed execution, one after another, each
+	// with its own scheduled time in RunInfo and FireInfo, so backfills
+	// process the time buckets they were meant to.
+	MisfireCatchUp
+)
+
+func (p MisfirePolicy) String() string {
+	switch p {
+	case MisfireFireNow:
+		return "fire-now"
+	case MisfireDoNothing:
+		return "do-nothing"
+	case MisfireRescheduleNext:
+		return "reschedule-next"
+	case MisfireCatchUp:
+		return "catch-up"
+	}
+	return "unknown"
+}
+
+func (p MisfirePolicy) MarshalText() ([]byte, error) {
+	return []byte(p.String()), nil
+}
+
+func (p *MisfirePolicy) UnmarshalText(text []byte) error {
+	for _, q := range []MisfirePolicy{MisfireFireNow, MisfireDoNothing, MisfireRescheduleNext, MisfireCatchUp} {
+		if q.String() == string(text) {
+			*p = q
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: misfire policy %s", ErrInvalidValue, text)
+}
+
+func WithMisfirePolicy(p MisfirePolicy) Option {
+	return func(t *Task) {
+		t.misfire = p
+	}
+}
+
+// misfired reports whether now is too far past the fire time next. If so,
+// it reports whether the missed fire should be dropped rather than run
+// immediately, and if dropped, when the task should fire instead; that is
+// the zero time if it never fires again.
+func (t *Task) misfired(s schedule, now, next time.Time) (at time.Time, drop, ok bool) {
+	if _, ok := s.(intervalSchedule); ok || t.dequeued || t.replaying || now.Sub(next) < misfireThreshold {
+		return time.Time{}, false, false
+	}
+
+	switch t.misfire {
+	case MisfireDoNothing:
+		return t.next(now), true, true
+	case MisfireRescheduleNext:
+		if after := s.next(next); !after.IsZero() {
+			return now.Add(after.Sub(next)), true, true
+		}
+		return time.Time{}, true, true
+	}
+	return time.Time{}, false, true
+}
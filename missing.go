@@ -0,0 +1,71 @@
+package every
+
+import (
+	"fmt"
+	"time"
+)
+
+// MissingFuncPolicy says what happens to a task whose func is missing,
+// because TaskDefinition.Build could not find it in the registry or the
+// task was created with a nil func.
+type MissingFuncPolicy int
+
+const (
+	// MissingFuncFail makes Build, and so Load and Apply, return an error.
+	// A task created with a nil func is disabled at its first fire.
+	MissingFuncFail MissingFuncPolicy = iota
+	// MissingFuncQuarantine builds the task as a disabled placeholder,
+	// listed by Quarantined, that Apply replaces once the func turns up.
+	MissingFuncQuarantine
+	// MissingFuncSkip builds the task, and skips each of its fires as
+	// SkipNoFunc with a warning, so the gap shows up in skip events.
+	MissingFuncSkip
+)
+
+func (p MissingFuncPolicy) String() string {
+	switch p {
+	case MissingFuncFail:
+		return "fail"
+	case MissingFuncQuarantine:
+		return "quarantine"
+	case MissingFuncSkip:
+		return "skip"
+	}
+	return "unknown"
+}
+
+func (p MissingFuncPolicy) MarshalText() ([]byte, error) {
+	return []byte(p.String()), nil
+}
+
+// WithMissingFunc sets the task's MissingFuncPolicy. Load and Apply build
+// tasks with the Scheduler's defaults, so SetDefaults sets it for them.
+func WithMissingFunc(p MissingFuncPolicy) Option {
+	return func(t *Task) {
+		t.missingFunc = p
+	}
+}
+
+// missing applies the task's policy to a func that could not be found
+// for reason, returning the error Build should fail with, if any.
+func (t *Task) missing(reason error) error {
+	switch t.missingFunc {
+	case MissingFuncQuarantine:
+		t.disabled = true
+		t.state.Store(int32(StateDisabled))
+	case MissingFuncSkip:
+	default:
+		return reason
+	}
+	return nil
+}
+
+// noFunc handles a fire of a task without a func.
+func (t *Task) noFunc(now time.Time) {
+	if t.missingFunc == MissingFuncSkip {
+		t.log.Warn("task has no func, skipping", "func", t.funcName)
+		t.passOver(now, SkipNoFunc)
+		return
+	}
+	t.disable(fmt.Errorf("%w: %s", ErrNoFunc, t.funcName))
+}
@@ -0,0 +1,142 @@
+package every
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+)
+
+// Namespace groups a Scheduler's tasks, such as those of one tenant or
+// team, under shared quotas and operations.
+type Namespace struct {
+	name     string
+	s        *Scheduler
+	maxTasks int
+	slots    chan struct{}
+	serial   *serialQueue
+	paused   atomic.Bool
+}
+
+// Namespace returns the namespace called name, creating it on first use.
+func (s *Scheduler) Namespace(name string) *Namespace {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	n, ok := s.ns[name]
+	if !ok {
+		if s.ns == nil {
+			s.ns = make(map[string]*Namespace)
+		}
+		n = &Namespace{name: name, s: s}
+		s.ns[name] = n
+	}
+	return n
+}
+
+func (n *Namespace) Name() string {
+	return n.name
+}
+
+// SetQuota limits the namespace to maxTasks tasks and maxConcurrent
+// executions at once; zero means no limit. Tasks already added are not
+// removed. Like SetPoolSize, it must be called before the tasks start.
+func (n *Namespace) SetQuota(maxTasks, maxConcurrent int) {
+	n.s.mu.Lock()
+	defer n.s.mu.Unlock()
+
+	n.maxTasks = maxTasks
+	n.slots = nil
+	if maxConcurrent > 0 {
+		n.slots = make(chan struct{}, maxConcurrent)
+	}
+}
+
+// Add registers t with the Scheduler as part of the namespace. It returns
+// ErrQuotaExceeded if the namespace already has its quota of tasks.
+func (n *Namespace) Add(t *Task) (TaskID, error) {
+	n.s.mu.Lock()
+	defer n.s.mu.Unlock()
+
+	if n.maxTasks > 0 && len(n.tasksLocked()) >= n.maxTasks {
+		return 0, fmt.Errorf("%w: %s has %d tasks", ErrQuotaExceeded, n.name, n.maxTasks)
+	}
+	t.ns = n
+	return n.s.addLocked(t), nil
+}
+
+// Tasks returns the namespace's tasks in registration order.
+func (n *Namespace) Tasks() []*Task {
+	n.s.mu.Lock()
+	defer n.s.mu.Unlock()
+
+	return n.tasksLocked()
+}
+
+func (n *Namespace) tasksLocked() []*Task {
+	var tasks []*Task
+	for _, t := range n.s.tasks {
+		if t.ns == n {
+			tasks = append(tasks, t)
+		}
+	}
+	return tasks
+}
+
+// Pause makes the namespace's tasks skip their fires, as SkipPaused,
+// until Resume. Runs in progress finish.
+func (n *Namespace) Pause() {
+	n.paused.Store(true)
+}
+
+func (n *Namespace) Resume() {
+	n.paused.Store(false)
+}
+
+func (n *Namespace) Paused() bool {
+	return n.paused.Load()
+}
+
+// acquire waits for one of the namespace's execution slots, and for its
+// turn if the namespace is serial, returning false if ctx is done first.
+// A nil Namespace has no limit.
+func (n *Namespace) acquire(ctx context.Context) bool {
+	if n == nil {
+		return true
+	}
+	if n.serial != nil && !n.serial.wait(ctx) {
+		return false
+	}
+	if n.slots == nil {
+		return true
+	}
+	select {
+	case n.slots <- struct{}{}:
+		return true
+	case <-ctx.Done():
+		if n.serial != nil {
+			n.serial.done()
+		}
+		return false
+	}
+}
+
+func (n *Namespace) release() {
+	if n == nil {
+		return
+	}
+	if n.slots != nil {
+		<-n.slots
+	}
+	if n.serial != nil {
+		n.serial.done()
+	}
+}
+
+// Namespace returns the name of the task's namespace, or "" if it has
+// none.
+func (t *Task) Namespace() string {
+	if t.ns == nil {
+		return ""
+	}
+	return t.ns.name
+}
@@ -0,0 +1,103 @@
+package every
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNamespaceQuota(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		maxTasks int
+		add      int
+		want     int
+	}{
+		{"unlimited", 0, 5, 5},
+		{"under quota", 3, 2, 2},
+		{"at quota", 3, 3, 3},
+		{"over quota", 3, 5, 3},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			s := NewScheduler()
+			s.Add(newTask(intervalSchedule(time.Hour), nil))
+			ns := s.Namespace("tenant")
+			ns.SetQuota(tc.maxTasks, 0)
+
+			var rejected int
+			for range tc.add {
+				task := newTask(intervalSchedule(time.Hour), nil)
+				if _, err := ns.Add(task); err != nil {
+					if !errors.Is(err, ErrQuotaExceeded) {
+						t.Fatalf("Add: %v, want ErrQuotaExceeded", err)
+					}
+					rejected++
+					continue
+				}
+				if task.Namespace() != "tenant" {
+					t.Errorf("Namespace() = %q, want tenant", task.Namespace())
+				}
+			}
+			if got := len(ns.Tasks()); got != tc.want || rejected != tc.add-tc.want {
+				t.Errorf("%d tasks, %d rejected; want %d, %d", got, rejected, tc.want, tc.add-tc.want)
+			}
+			if s.Namespace("tenant") != ns || len(s.Tasks()) != tc.want+1 {
+				t.Errorf("scheduler holds %d tasks, want the namespace's %d and one more", len(s.Tasks()), tc.want)
+			}
+		})
+	}
+}
+
+func TestNamespaceConcurrency(t *testing.T) {
+	ns := NewScheduler().Namespace("tenant")
+	ns.SetQuota(0, 2)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if !ns.acquire(ctx) || !ns.acquire(ctx) {
+		t.Fatal("acquire failed under the limit")
+	}
+	if ns.acquire(ctx) {
+		t.Fatal("acquire succeeded over the limit")
+	}
+	ns.release()
+	if !ns.acquire(context.Background()) {
+		t.Error("acquire failed after a release")
+	}
+}
+
+func TestNamespacePause(t *testing.T) {
+	s := NewScheduler()
+	ns := s.Namespace("tenant")
+	var skipped []SkipReason
+	task, err := NewTask("1s", func() {}, OnSkip(func(r SkipReason) { skipped = append(skipped, r) }))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ns.Add(task); err != nil {
+		t.Fatal(err)
+	}
+	task.timer = timers.get()
+	task.begin()
+	defer timers.put(task.timer)
+
+	ns.Pause()
+	if !task.Paused() {
+		t.Error("task not paused with its namespace")
+	}
+	now := time.Now()
+	task.due = now
+	task.fire(now)
+	if task.runs != 0 || len(skipped) != 1 || skipped[0] != SkipPaused {
+		t.Errorf("paused fire: %d runs, skips %v", task.runs, skipped)
+	}
+
+	ns.Resume()
+	now = time.Now()
+	task.due = now
+	task.fire(now)
+	if task.runs != 1 {
+		t.Errorf("resumed fire: %d runs, want 1", task.runs)
+	}
+}
@@ -0,0 +1,280 @@
+package every
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"net/smtp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+type EventType int
+
+const (
+	// EventFailure is sent when a run returns an error.
+	EventFailure EventType = iota
+	// EventRecovery is sent for the first successful run after a failure.
+	EventRecovery
+	// EventSkip is sent when a fire is suppressed instead of run.
+	EventSkip
+	// EventDisabled is sent when a policy such as WithPanicLimit takes the
+	// task out of service.
+	EventDisabled
+	// EventPreempted is sent when a run is cancelled to make room for a
+	// preempting task.
+	EventPreempted
+	// EventClockStep is sent when the wall clock is found to have jumped
+	// relative to the monotonic clock, from an NTP step, a manual change
+	// or a suspend. Interval tasks are unaffected; calendar tasks follow
+	// the new wall time, subject to their misfire policy.
+	EventClockStep
+	// EventFailover is sent when a Scheduler running as a standby takes
+	// over the lease and starts running its tasks.
+	EventFailover
+	// EventGap is sent when a run follows fires that did not run, with
+	// Missed set to how many; see RunInfo.Sequence.
+	EventGap
+	// EventTimeout is sent in place of EventFailure when a run fails by
+	// outliving its WithTimeout limit.
+	EventTimeout
+	// EventCircuitOpen is sent when a WithCircuitBreaker circuit opens,
+	// with Duration set to how long it stays open and Err to the failure
+	// that opened it.
+	EventCircuitOpen
+)
+
+func (e EventType) String() string {
+	switch e {
+	case EventFailure:
+		return "failure"
+	case EventRecovery:
+		return "recovery"
+	case EventSkip:
+		return "skip"
+	case EventDisabled:
+		return "disabled"
+	case EventPreempted:
+		return "preempted"
+	case EventClockStep:
+		return "clock-step"
+	case EventFailover:
+		return "failover"
+	case EventGap:
+		return "gap"
+	case EventTimeout:
+		return "timeout"
+	case EventCircuitOpen:
+		return "circuit-open"
+	}
+	return "unknown"
+}
+
+func (e EventType) MarshalText() ([]byte, error) {
+	return []byte(e.String()), nil
+}
+
+// Event describes something a Notifier is told about. Run, Duration and
+// Err are only set for failures, timeouts and recoveries, Reason only for
+// skips. A disabled task's Err says why; a clock step's Duration says by
+// how much.
+type Event struct {
+	Type     EventType
+	Task     string
+	Time     time.Time
+	Run      uint64
+	Sequence uint64
+	Missed   int
+	Duration time.Duration
+	Err      error
+	Reason   SkipReason
+}
+
+func (e Event) String() string {
+	switch e.Type {
+	case EventFailure:
+		return fmt.Sprintf("task %s failed: %v", e.Task, e.Err)
+	case EventRecovery:
+		return fmt.Sprintf("task %s recovered", e.Task)
+	case EventSkip:
+		return fmt.Sprintf("task %s skipped a run (%s)", e.Task, e.Reason)
+	case EventDisabled:
+		return fmt.Sprintf("task %s disabled: %v", e.Task, e.Err)
+	case EventPreempted:
+		return fmt.Sprintf("task %s %v", e.Task, e.Err)
+	case EventClockStep:
+		return fmt.Sprintf("task %s saw the clock step by %s", e.Task, e.Duration)
+	case EventFailover:
+		return fmt.Sprintf("task %s taken over by this instance", e.Task)
+	case EventGap:
+		return fmt.Sprintf("task %s missed %d fires before run %d", e.Task, e.Missed, e.Run)
+	case EventTimeout:
+		return fmt.Sprintf("task %s timed out: %v", e.Task, e.Err)
+	case EventCircuitOpen:
+		return fmt.Sprintf("task %s circuit open for %s: %v", e.Task, e.Duration, e.Err)
+	}
+	return fmt.Sprintf("task %s: %s", e.Task, e.Type)
+}
+
+type Notifier interface {
+	Notify(ctx context.Context, e Event) error
+}
+
+type notifier struct {
+	n     Notifier
+	types []EventType
+}
+
+// WithNotifier sends the given event types to n, or only failures and
+// timeouts if none are given. Notifications are delivered in the
+// background, one at a time and in order, so a slow Notifier never delays
+// the task; errors are logged. Events are dropped while 64 others are
+// waiting, and once the task is stopped.
+func WithNotifier(n Notifier, types ...EventType) Option {
+	if len(types) == 0 {
+		types = []EventType{EventFailure, EventTimeout}
+	}
+	return func(t *Task) {
+		t.notifiers = append(t.notifiers, notifier{n, types})
+	}
+}
+
+// failureEvent returns the event type a run failing with err is reported
+// as.
+func failureEvent(err error) EventType {
+	if errors.Is(err, ErrTimeout) {
+		return EventTimeout
+	}
+	return EventFailure
+}
+
+// notifyBacklog is how many events a task queues for its notifiers.
+const notifyBacklog = 64
+
+// outbox queues a task's events for a worker that delivers them to its
+// notifiers. The worker starts with the first event and exits once the
+// outbox is closed and drained.
+type outbox struct {
+	mu     sync.Mutex
+	events chan Event
+	closed bool
+}
+
+func (t *Task) notify(e Event) {
+	if !slices.ContainsFunc(t.notifiers, func(n notifier) bool { return slices.Contains(n.types, e.Type) }) {
+		return
+	}
+	e.Task = t.name
+
+	o := &t.outbox
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.closed {
+		return
+	}
+	if o.events == nil {
+		o.events = make(chan Event, notifyBacklog)
+		go t.deliver(o.events)
+	}
+	select {
+	case o.events <- e:
+	default:
+		t.Logger().Warn("notification dropped, too many pending", "event", e.Type)
+	}
+}
+
+func (t *Task) deliver(events <-chan Event) {
+	for e := range events {
+		for _, n := range t.notifiers {
+			if !slices.Contains(n.types, e.Type) {
+				continue
+			}
+			if err := n.n.Notify(context.Background(), e); err != nil {
+				t.Logger().Warn("notification failed", "event", e.Type, "error", err)
+			}
+		}
+	}
+}
+
+// closeOutbox lets the worker finish the events already queued and exit.
+func (t *Task) closeOutbox() {
+	o := &t.outbox
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if !o.closed && o.events != nil {
+		close(o.events)
+	}
+	o.closed = true
+}
+
+// SlackNotifier posts each event's String as a message to a Slack incoming
+// webhook.
+type SlackNotifier struct {
+	WebhookURL string
+	// Client defaults to an http.Client with a 10s timeout.
+	Client *http.Client
+}
+
+func (s *SlackNotifier) Notify(ctx context.Context, e Event) error {
+	body, err := json.Marshal(map[string]string{"text": e.String()})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.WebhookURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := s.Client
+	if client == nil {
+		client = &http.Client{Timeout: webhookTimeout}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("slack: %s", resp.Status)
+	}
+	return nil
+}
+
+// SMTPNotifier emails each event through the server at Addr, using the
+// event's String as the subject.
+type SMTPNotifier struct {
+	Addr string
+	Auth smtp.Auth
+	From string
+	To   []string
+}
+
+func (s *SMTPNotifier) Notify(_ context.Context, e Event) error {
+	return smtp.SendMail(s.Addr, s.Auth, s.From, s.To, s.message(e))
+}
+
+// message formats e as an email. Line breaks in the subject, which may
+// come from an error message, are flattened so they cannot start headers
+// of their own, and non-ASCII text is encoded.
+func (s *SMTPNotifier) message(e Event) []byte {
+	subject := strings.Join(strings.Fields(e.String()), " ")
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", s.From)
+	fmt.Fprintf(&b, "To: %s\r\n", strings.Join(s.To, ", "))
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&b, "Date: %s\r\n\r\n", e.Time.Format(time.RFC1123Z))
+	fmt.Fprintf(&b, "%s at %s\r\n", e, e.Time.Format(time.RFC3339))
+	if e.Duration > 0 {
+		fmt.Fprintf(&b, "Run %d took %s.\r\n", e.Run, e.Duration)
+	}
+	return []byte(b.String())
+}
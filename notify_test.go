@@ -0,0 +1,76 @@
+package every
+
+import (
+	"context"
+	"errors"
+	"mime"
+	"net/mail"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSMTPSubjectCannotAddHeaders(t *testing.T) {
+	s := &SMTPNotifier{From: "every@example.com", To: []string{"ops@example.com"}}
+	e := Event{Type: EventFailure, Task: "sync", Time: time.Now(), Err: errors.New("bad\r\nBcc: victim@example.com\r\n\r\nhi")}
+
+	msg, err := mail.ReadMessage(strings.NewReader(string(s.message(e))))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bcc := msg.Header.Get("Bcc"); bcc != "" {
+		t.Errorf("error text injected a Bcc header: %q", bcc)
+	}
+	subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(subject, "bad Bcc: victim@example.com hi") {
+		t.Errorf("Subject = %q, want the error on one line", subject)
+	}
+}
+
+// gatedNotifier records events, each Notify waiting for open to be closed.
+type gatedNotifier struct {
+	open chan struct{}
+	got  chan uint64
+}
+
+func (n *gatedNotifier) Notify(_ context.Context, e Event) error {
+	<-n.open
+	n.got <- e.Run
+	return nil
+}
+
+func TestNotifyQueueIsBoundedAndOrdered(t *testing.T) {
+	n := &gatedNotifier{open: make(chan struct{}), got: make(chan uint64, 2*notifyBacklog)}
+	task, err := NewTask("1s", func() {}, WithNotifier(n, EventSkip))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for run := range uint64(2 * notifyBacklog) {
+		task.notify(Event{Type: EventSkip, Run: run})
+	}
+	task.closeOutbox()
+	task.notify(Event{Type: EventSkip, Run: 2 * notifyBacklog})
+	close(n.open)
+
+	var runs []uint64
+	for {
+		select {
+		case run := <-n.got:
+			runs = append(runs, run)
+			continue
+		case <-time.After(100 * time.Millisecond):
+		}
+		break
+	}
+	if len(runs) < notifyBacklog || len(runs) > notifyBacklog+1 {
+		t.Errorf("%d events delivered, want the %d queued plus at most one in flight", len(runs), notifyBacklog)
+	}
+	for i, run := range runs {
+		if run != uint64(i) {
+			t.Fatalf("events delivered as %v, want in order from 0", runs)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package every
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// onceSchedule fires a single time, at an absolute wall-clock time. Like
+// other calendar schedules it is rechecked every wakeCheck, so clock steps
+// and suspends cannot make it fire far from at.
+type onceSchedule struct {
+	at time.Time
+}
+
+func (s onceSchedule) next(t time.Time) time.Time {
+	if t.Before(s.at) {
+		return s.at
+	}
+	return time.Time{}
+}
+
+func (s onceSchedule) String() string {
+	return "at@" + s.at.Format(time.RFC3339)
+}
+
+func parseOnce(spec, at string) (schedule, error) {
+	t, err := time.Parse(time.RFC3339, at)
+	if err != nil {
+		return nil, fmt.Errorf("%w: time %s", ErrInvalidValue, spec)
+	}
+	return onceSchedule{t}, nil
+}
+
+// RunAt starts a task that runs fn once at the given time and then stays
+// idle until stopped. If at has already passed when the task starts, the
+// misfire policy decides whether it runs at once or never. The same
+// schedule is written "at@" followed by an RFC 3339 time for NewTask.
+// RunAt returns any error from starting the task.
+func RunAt(at time.Time, fn func(), opts ...Option) (*Task, error) {
+	t := newTask(onceSchedule{at}, func(context.Context) error {
+		fn()
+		return nil
+	})
+	t.apply(opts)
+	if err := t.TryStart(); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
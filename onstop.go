@@ -0,0 +1,22 @@
+package every
+
+import "context"
+
+// OnStop registers fn to be called once the task has stopped for good:
+// from Stop, after any run in progress and the final run have finished, or
+// when a Supervisor gives up on the task. It is called exactly once, with
+// a context that carries the task's values but is not cancelled.
+func OnStop(fn func(ctx context.Context)) Option {
+	return func(t *Task) {
+		t.onStop = fn
+	}
+}
+
+func (t *Task) stopped() {
+	if t.onStop == nil {
+		return
+	}
+	t.hookOnce.Do(func() {
+		t.onStop(context.WithoutCancel(t.ctx))
+	})
+}
@@ -0,0 +1,43 @@
+package every
+
+import "time"
+
+type Option func(*Task)
+
+func WithName(name string) Option {
+	return func(t *Task) {
+		t.name = name
+	}
+}
+
+// WithStrictParsing rejects intervals that repeat a unit or list units out
+// of order, such as "30m1h", and schedules with surrounding whitespace,
+// which are otherwise accepted. It is meant for validating hand-written
+// configuration.
+func WithStrictParsing() Option {
+	return func(t *Task) {
+		t.parser.strict = true
+	}
+}
+
+// defaultMinInterval is the shortest interval tasks accept by default.
+// Intervals are whole seconds, so it only rules out zero.
+const defaultMinInterval = time.Second
+
+// WithMinInterval sets the shortest interval the task accepts, at creation
+// and in UpdateInterval, so a typo such as "0s" cannot make it spin. It
+// defaults to 1s; a zero interval is always rejected.
+func WithMinInterval(d time.Duration) Option {
+	return func(t *Task) {
+		t.parser.minInterval = max(d, 1)
+	}
+}
+
+// WithSeconds makes cron expressions take a leading seconds field, so
+// "*/10 * * * * *" fires every ten seconds. Five-field expressions are
+// rejected while it is set.
+func WithSeconds() Option {
+	return func(t *Task) {
+		t.parser.seconds = true
+	}
+}
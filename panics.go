@@ -0,0 +1,47 @@
+package every
+
+import (
+	"fmt"
+	"runtime/debug"
+	"time"
+)
+
+// WithPanicLimit recovers panics in the task func, recording each as a
+// failed run, and disables the task once it has panicked n times within
+// window, sending EventDisabled to its notifiers. A disabled task stays
+// registered but never fires.
+func WithPanicLimit(n int, window time.Duration) Option {
+	return func(t *Task) {
+		t.panicLimit, t.panicWindow = n, window
+	}
+}
+
+// panicked records a panic recovered from the task func and returns the
+// error the run fails with.
+func (t *Task) panicked(r any) error {
+	err := fmt.Errorf("panic: %v", r)
+	t.log.Error("task panicked", "error", err, "stack", string(debug.Stack()))
+
+	now := time.Now()
+	for len(t.panics) > 0 && now.Sub(t.panics[0]) > t.panicWindow {
+		t.panics = t.panics[1:]
+	}
+	t.panics = append(t.panics, now)
+	if len(t.panics) >= t.panicLimit {
+		t.disabling = fmt.Errorf("panicked %d times within %s", len(t.panics), formatDuration(t.panicWindow))
+	}
+	return err
+}
+
+// disable takes the task out of service for reason.
+func (t *Task) disable(reason error) {
+	t.disabling = nil
+	t.disabled = true
+	t.panics = nil
+	t.arm()
+	t.setState(StateDisabled, time.Time{})
+	t.log.Error("task disabled", "reason", reason)
+	if t.notifiers != nil {
+		t.notify(Event{Type: EventDisabled, Time: time.Now(), Err: reason})
+	}
+}
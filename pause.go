@@ -0,0 +1,17 @@
+package every
+
+// Pause makes the task skip its fires, as SkipPaused, until Resume. Its
+// schedule carries on meanwhile, and a run in progress finishes.
+func (t *Task) Pause() {
+	t.paused.Store(true)
+}
+
+func (t *Task) Resume() {
+	t.paused.Store(false)
+}
+
+// Paused reports whether the task is paused, on its own or with its
+// Namespace.
+func (t *Task) Paused() bool {
+	return t.paused.Load() || t.ns != nil && t.ns.Paused()
+}
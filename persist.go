@@ -0,0 +1,112 @@
+package every
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// scheduleKey is the JobStore key runtime schedule changes are saved
+// under, next to the task's StateBag.
+func (t *Task) scheduleKey() string {
+	return t.name + "#schedule"
+}
+
+// persists reports whether the task saves its schedule changes.
+func (t *Task) persists() bool {
+	return t.store != nil && t.name != ""
+}
+
+// baseline returns the schedule the task was built with. It must be
+// called with versionMu held, before the first change.
+func (t *Task) baseline() string {
+	if t.built == "" {
+		t.built = t.currentSchedule().String()
+	}
+	return t.built
+}
+
+// saveSchedule records the task's schedule, history and version in its
+// JobStore. It is called with versionMu held.
+func (t *Task) saveSchedule() {
+	if !t.persists() {
+		return
+	}
+	history := make([]string, len(t.history))
+	for i, s := range t.history {
+		history[i] = s.String()
+	}
+	past, _ := json.Marshal(history)
+	values := map[string][]byte{
+		"built":   []byte(t.baseline()),
+		"spec":    []byte(t.currentSchedule().String()),
+		"history": past,
+		"version": []byte(strconv.FormatUint(t.version, 10)),
+	}
+	if err := t.store.Save(t.scheduleKey(), values); err != nil {
+		t.Logger().Warn("schedule not saved", "error", err)
+	}
+}
+
+// restoreSchedule is called as the task starts. It brings back the
+// schedule and history saved by an earlier process, unless the task has
+// since been built with a different schedule, which then wins.
+func (t *Task) restoreSchedule() {
+	if !t.persists() {
+		return
+	}
+	t.versionMu.Lock()
+	defer t.versionMu.Unlock()
+	if t.version > 0 {
+		return
+	}
+
+	log := t.Logger()
+	values, err := t.store.Load(t.scheduleKey())
+	if err != nil {
+		log.Warn("saved schedule unreadable", "error", err)
+		return
+	}
+	spec, ok := values["spec"]
+	if !ok {
+		return
+	}
+	if built := string(values["built"]); built != t.baseline() {
+		log.Info("saved schedule ignored for new build", "saved", string(spec), "built", t.built)
+		return
+	}
+	s, history, version, err := t.parseSaved(values)
+	if err != nil {
+		log.Warn("saved schedule unreadable", "error", err)
+		return
+	}
+	t.mu.Lock()
+	t.schedule = s
+	t.mu.Unlock()
+	t.history, t.version = history, version
+	log.Debug("schedule restored", "schedule", s, "version", version+1)
+}
+
+func (t *Task) parseSaved(values map[string][]byte) (schedule, []schedule, uint64, error) {
+	s, err := t.parser.parse(string(values["spec"]))
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	var specs []string
+	if err := json.Unmarshal(values["history"], &specs); err != nil {
+		return nil, nil, 0, fmt.Errorf("schedule history: %w", err)
+	}
+	history := make([]schedule, 0, len(specs))
+	for _, spec := range specs {
+		prev, err := t.parser.parse(spec)
+		if err != nil {
+			return nil, nil, 0, err
+		}
+		history = append(history, prev)
+	}
+	version, err := strconv.ParseUint(string(values["version"]), 10, 64)
+	if err != nil {
+		return nil, nil, 0, fmt.Errorf("schedule version: %w", err)
+	}
+	return s, history, version, nil
+}
@@ -0,0 +1,22 @@
+package every
+
+import "time"
+
+// WithPhase makes the task shard k of n: an interval task fires at the
+// points of a fixed grid offset by k/n of its interval, so n shards of one
+// job, in one process or across a fleet with synced clocks, take turns
+// evenly instead of bunching up. With "1m" and n of 4, shard 1 fires at
+// 15s past every minute. Runs that overrun a grid point wait for the next
+// one. Calendar schedules ignore the phase.
+func WithPhase(k, n int) Option {
+	return func(t *Task) {
+		t.phase, t.phases = k, n
+	}
+}
+
+// phased returns the first point of the task's phase grid for the interval
+// d after now.
+func (t *Task) phased(d time.Duration, now time.Time) time.Time {
+	offset := time.Duration(float64(d) * float64(t.phase) / float64(t.phases))
+	return anchoredSchedule{every: d, anchor: time.Unix(0, 0).Add(offset)}.next(now)
+}
@@ -0,0 +1,174 @@
+package every
+
+import (
+	"context"
+	"math"
+	"sync"
+)
+
+// pool limits how many executions run at once across a Scheduler's tasks.
+// When tasks queue for a slot they are served by start-time fair queuing:
+// each task carries a virtual finish tag that advances by 1/weight per
+// execution, and the waiter with the smallest start tag goes next, ties in
+// registration order so tasks queued by the same instant are served
+// predictably. A task may fall at most one unit of virtual time behind
+// while it waits out its interval, so briefly idle tasks keep their share
+// without banking a burst.
+//
+// The guarantee: while tasks contend, each receives slots in proportion to
+// its weight, limited only by its own demand (a task has at most one
+// execution pending), and every waiter is served after a bounded number of
+// executions of the others, so no task can be starved.
+//
+// A preempting task skips the queue instead, and makes room by cancelling
+// a lower-priority execution if the pool is full; see WithPreemption.
+// Waiters held back by resource limits let those behind them go first.
+type pool struct {
+	mu      sync.Mutex
+	free    int
+	limits  ResourceLimits
+	cpu, io int
+	memory  int
+	vtime   float64
+	finish  map[*Task]float64
+	waiters []*poolWaiter
+	running map[*Task]context.CancelCauseFunc
+}
+
+type poolWaiter struct {
+	task  *Task
+	start float64
+	ready chan struct{}
+}
+
+func (w *poolWaiter) after(other *poolWaiter) bool {
+	if w.start != other.start {
+		return w.start > other.start
+	}
+	return w.task.id > other.task.id
+}
+
+func newPool(size int, limits ResourceLimits) *pool {
+	return &pool{free: size, limits: limits, finish: make(map[*Task]float64), running: make(map[*Task]context.CancelCauseFunc)}
+}
+
+// acquire blocks until t may run, or returns false if ctx is done first.
+func (p *pool) acquire(ctx context.Context, t *Task) bool {
+	p.mu.Lock()
+	start := max(p.vtime-1, p.finish[t])
+	p.finish[t] = start + 1/t.weight()
+	if p.free > 0 && p.fits(t) && (len(p.waiters) == 0 || t.preempting) {
+		p.take(t)
+		p.vtime = start
+		p.mu.Unlock()
+		return true
+	}
+
+	w := &poolWaiter{task: t, start: start, ready: make(chan struct{})}
+	i := len(p.waiters)
+	for i > 0 && p.waiters[i-1].after(w) {
+		i--
+	}
+	if t.preempting {
+		for i = 0; i < len(p.waiters) && p.waiters[i].task.preempting; i++ {
+		}
+		p.preemptLocked(t)
+	}
+	p.waiters = append(p.waiters, nil)
+	copy(p.waiters[i+1:], p.waiters[i:])
+	p.waiters[i] = w
+	p.mu.Unlock()
+
+	select {
+	case <-w.ready:
+		return true
+	case <-ctx.Done():
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for i, other := range p.waiters {
+		if other == w {
+			p.waiters = append(p.waiters[:i], p.waiters[i+1:]...)
+			return false
+		}
+	}
+	// The slot was handed over just as ctx finished; pass it on.
+	p.giveBack(t)
+	p.releaseLocked()
+	return false
+}
+
+// started records the cancel func of t's execution, so a preempting task
+// can end it early.
+func (p *pool) started(t *Task, cancel context.CancelCauseFunc) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.running[t] = cancel
+}
+
+func (p *pool) release(t *Task) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	delete(p.running, t)
+	p.giveBack(t)
+	p.releaseLocked()
+}
+
+// releaseLocked hands free slots to the waiters first in line whose
+// resources fit.
+func (p *pool) releaseLocked() {
+	for i := 0; i < len(p.waiters) && p.free > 0; {
+		w := p.waiters[i]
+		if !p.fits(w.task) {
+			i++
+			continue
+		}
+		p.waiters = append(p.waiters[:i], p.waiters[i+1:]...)
+		p.take(w.task)
+		p.vtime = w.start
+		close(w.ready)
+	}
+}
+
+// WithWeight sets the task's share of a Scheduler's worker pool relative to
+// other tasks; the default is 1.
+func WithWeight(weight float64) Option {
+	return func(t *Task) {
+		t.poolWeight = weight
+	}
+}
+
+func (t *Task) weight() float64 {
+	if t.poolWeight <= 0 {
+		return 1
+	}
+	return t.poolWeight
+}
+
+// SetPoolSize limits the Scheduler's tasks to n concurrent executions,
+// shared by weight. A size of 0 removes the limit. It must be called
+// before the tasks are started.
+func (s *Scheduler) SetPoolSize(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.poolSize = n
+	s.resetPoolLocked()
+}
+
+func (s *Scheduler) resetPoolLocked() {
+	s.pool = nil
+	if s.poolSize > 0 || s.limits != (ResourceLimits{}) {
+		size := s.poolSize
+		if size <= 0 {
+			size = math.MaxInt
+		}
+		s.pool = newPool(size, s.limits)
+	}
+	for _, t := range s.tasks {
+		t.pool = s.pool
+	}
+}
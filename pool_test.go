@@ -0,0 +1,74 @@
+package every
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestPoolSharesFollowWeights checks the pool's fairness guarantee: tasks
+// that always have an execution pending get slots in proportion to their
+// weights, and none waits long behind the others.
+func TestPoolSharesFollowWeights(t *testing.T) {
+	// A running task has nothing pending, so in a one-slot pool it can
+	// take at most every other slot; no weight here is over half the total.
+	weights := []float64{3, 2, 1, 1, 1}
+	const total = 800
+
+	p := newPool(1, ResourceLimits{})
+	tasks := make([]*Task, len(weights))
+	for i, w := range weights {
+		tasks[i] = &Task{id: TaskID(i + 1)}
+		tasks[i].poolWeight = w
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var (
+		mu    sync.Mutex
+		order []int
+		wg    sync.WaitGroup
+	)
+	for i, task := range tasks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for p.acquire(ctx, task) {
+				mu.Lock()
+				order = append(order, i)
+				done := len(order) >= total
+				mu.Unlock()
+				if done {
+					cancel()
+				} else {
+					time.Sleep(100 * time.Microsecond)
+				}
+				p.release(task)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// The first grants go out before every task has queued.
+	order = order[len(weights):total]
+	var sum float64
+	for _, w := range weights {
+		sum += w
+	}
+	counts := make([]int, len(weights))
+	last := make([]int, len(weights))
+	for n, i := range order {
+		counts[i]++
+		if gap, limit := n-last[i], int(sum/weights[i])+len(weights); gap > limit {
+			t.Errorf("task %d waited %d executions of the others, over %d", i, gap, limit)
+		}
+		last[i] = n
+	}
+	for i, w := range weights {
+		want := float64(len(order)) * w / sum
+		if got := float64(counts[i]); got < want*0.9 || got > want*1.1 {
+			t.Errorf("task %d with weight %g got %d of %d slots, want about %.0f", i, w, counts[i], len(order), want)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package every
+
+import (
+	"fmt"
+	"time"
+)
+
+// WithPriority sets the task's priority for preemption in a Scheduler's
+// worker pool; higher values are more important. The default is 0.
+func WithPriority(priority int) Option {
+	return func(t *Task) {
+		t.priority = priority
+	}
+}
+
+// WithPreemption lets the task take a slot in a Scheduler's full worker
+// pool from a running task of lower priority, whose run context is
+// cancelled with ErrPreempted as the cause. The pool hands the freed slot,
+// and any other, to preempting tasks before queued ones. When nothing of
+// lower priority is running the task waits at the head of the queue.
+func WithPreemption() Option {
+	return func(t *Task) {
+		t.preempting = true
+	}
+}
+
+// preemptLocked cancels the running execution of lowest priority below
+// t's, ties going to the most recently registered task.
+func (p *pool) preemptLocked(t *Task) {
+	var victim *Task
+	for other := range p.running {
+		if other.priority >= t.priority {
+			continue
+		}
+		if victim == nil || other.priority < victim.priority || other.priority == victim.priority && other.id > victim.id {
+			victim = other
+		}
+	}
+	if victim == nil {
+		return
+	}
+
+	cancel := p.running[victim]
+	delete(p.running, victim)
+	err := fmt.Errorf("%w by %s", ErrPreempted, t.name)
+	cancel(ErrPreempted)
+	victim.Logger().Warn("task preempted", "by", t.name)
+	if victim.notifiers != nil {
+		victim.notify(Event{Type: EventPreempted, Time: time.Now(), Err: err})
+	}
+}
@@ -0,0 +1,125 @@
+package every
+
+import (
+	"fmt"
+	"io"
+	"time"
+)
+
+// Schedule is a parsed schedule spec, usable on its own to check what a
+// spec means before giving it to a task.
+type Schedule struct {
+	s schedule
+}
+
+// ParseSchedule parses spec as NewTask would. Of opts, only those that
+// affect parsing, such as WithSeconds and WithCoordinates, have any
+// effect.
+func ParseSchedule(spec string, opts ...Option) (Schedule, error) {
+	var t Task
+	for _, opt := range opts {
+		opt(&t)
+	}
+	s, err := t.parser.parse(spec)
+	if err != nil {
+		return Schedule{}, err
+	}
+	return Schedule{s}, nil
+}
+
+// Next returns the first fire time after t, or the zero time if there is
+// none.
+func (s Schedule) Next(t time.Time) time.Time {
+	return s.s.next(t)
+}
+
+// Preview returns up to n fire times following from. Intervals are
+// measured from from itself, as if each run took no time.
+func (s Schedule) Preview(from time.Time, n int) []time.Time {
+	var times []time.Time
+	for next := from; len(times) < n; {
+		if next = s.s.next(next); next.IsZero() {
+			break
+		}
+		times = append(times, next)
+	}
+	return times
+}
+
+func (s Schedule) String() string {
+	return s.s.String()
+}
+
+// preview returns up to n fire times after from taking the task's
+// calendar, roll policy and splay into account, but not its ramp.
+func (t *Task) preview(from time.Time, n int) []time.Time {
+	if t.events != nil {
+		return nil
+	}
+
+	p := t.planner()
+	next, _ := p.first(from)
+	return p.upcoming(next, n)
+}
+
+// NextN returns the task's next n fire times, starting with the one it is
+// waiting for, if any. Later times assume each run takes no time, so for
+// intervals they drift by however long the runs actually take. Triggered
+// tasks return at most their pending fire.
+func (t *Task) NextN(n int) []time.Time {
+	if n <= 0 {
+		return nil
+	}
+
+	next := t.nextRun.Load()
+	switch {
+	case t.events != nil && next.IsZero():
+		return nil
+	case t.events != nil:
+		return []time.Time{next}
+	case next.IsZero():
+		return t.preview(time.Now(), n)
+	}
+	return t.planner().upcoming(next, n)
+}
+
+// planner returns a task holding just the settings that decide fire times,
+// which can be stepped through away from the run loop.
+func (t *Task) planner() *Task {
+	return &Task{
+		schedule: t.currentSchedule(),
+		settings: settings{
+			calendar:     t.calendar,
+			businessDays: t.businessDays,
+			roll:         t.roll,
+			splay:        t.splay,
+			phase:        t.phase,
+			phases:       t.phases,
+		},
+	}
+}
+
+func (t *Task) upcoming(next time.Time, n int) []time.Time {
+	var times []time.Time
+	for ; !next.IsZero() && len(times) < n; next = t.next(next) {
+		times = append(times, next)
+	}
+	return times
+}
+
+// DryRun writes the next n fire times after from of every task to w,
+// without running anything. Triggered tasks such as debouncers have no
+// fire times to show.
+func (s *Scheduler) DryRun(w io.Writer, from time.Time, n int) error {
+	for _, t := range s.Tasks() {
+		if _, err := fmt.Fprintln(w, t.Name()+" ("+t.Schedule()+")"); err != nil {
+			return err
+		}
+		for _, next := range t.preview(from, n) {
+			if _, err := fmt.Fprintln(w, "  "+next.Format(time.RFC3339)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
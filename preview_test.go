@@ -0,0 +1,54 @@
+package every
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestNextNMatchesPhasedFires(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		k, n      int
+		remainder time.Duration
+	}{
+		{"quarter", 1, 4, 250 * time.Millisecond},
+		{"three quarters", 3, 4, 750 * time.Millisecond},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			fired := make(chan time.Time, 2)
+			task, err := NewContextTask("1s", func(ctx context.Context) {
+				info, _ := RunInfoFrom(ctx)
+				select {
+				case fired <- info.Scheduled:
+				default:
+				}
+			}, WithPhase(tc.k, tc.n))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			want := task.NextN(2)
+			if len(want) != 2 {
+				t.Fatalf("NextN(2) = %v", want)
+			}
+			if err := task.TryStart(); err != nil {
+				t.Fatal(err)
+			}
+			defer task.Stop()
+			for i, w := range want {
+				if got := w.UnixNano() % int64(time.Second); got != int64(tc.remainder) {
+					t.Errorf("NextN[%d] = %v, off the phase grid by %v", i, w, time.Duration(got))
+				}
+				select {
+				case got := <-fired:
+					if !got.Equal(w) {
+						t.Errorf("fire %d due at %v, NextN said %v", i, got, w)
+					}
+				case <-time.After(2 * time.Second):
+					t.Fatalf("fire %d did not happen", i)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package every
+
+import (
+	"context"
+	"runtime/pprof"
+	"strconv"
+)
+
+// WithProfileLabels runs the task func under pprof labels naming the task
+// and the run, so CPU profiles attribute the time spent to it. The labels
+// are "every.task", the task's name, and "every.run", its run ID.
+func WithProfileLabels() Option {
+	return func(t *Task) {
+		t.profileLabels = true
+	}
+}
+
+func (t *Task) labelled(ctx context.Context, fn func(context.Context)) {
+	r, _ := runFrom(ctx)
+	labels := pprof.Labels("every.task", t.name, "every.run", strconv.FormatUint(r.info.ID, 10))
+	pprof.Do(ctx, labels, fn)
+}
@@ -0,0 +1,60 @@
+package every
+
+import (
+	"context"
+	"time"
+)
+
+type taskKey struct{}
+
+type Progress struct {
+	Done    int64     `json:"done"`
+	Total   int64     `json:"total"`
+	Message string    `json:"message"`
+	Updated time.Time `json:"updated"`
+}
+
+// Reporter lets a running task publish how far along it is, for example
+// Report(4000, 10000, "rows"). Progress is cleared at the start of each run.
+type Reporter struct {
+	task *Task
+}
+
+// ReporterFrom returns the Reporter for the run ctx belongs to. It returns
+// nil outside a task run; a nil Reporter discards reports.
+func ReporterFrom(ctx context.Context) *Reporter {
+	r, ok := runFrom(ctx)
+	if !ok {
+		return nil
+	}
+	return &Reporter{task: r.task}
+}
+
+func (r *Reporter) Report(done, total int64, message string) {
+	if r == nil {
+		return
+	}
+
+	r.task.progress.Store(&Progress{Done: done, Total: total, Message: message, Updated: time.Now()})
+}
+
+// Progress returns the latest progress reported by the current or most
+// recent run.
+func (t *Task) Progress() Progress {
+	if p := t.progress.Load(); p != nil {
+		return *p
+	}
+	return Progress{}
+}
+
+// IsStopping reports whether the task running with ctx is being stopped.
+// It is a single atomic load once the task is found, cheap enough to poll
+// from tight loops; outside a task run it reports false.
+func IsStopping(ctx context.Context) bool {
+	r, ok := runFrom(ctx)
+	return ok && r.task.stopping.Load()
+}
+
+func (t *Task) IsStopping() bool {
+	return t.stopping.Load()
+}
@@ -0,0 +1,72 @@
+package every
+
+import "time"
+
+// Quarantined returns the tasks taken out of service by a policy such as
+// WithPanicLimit, which stay registered but do not fire until re-enabled.
+func (s *Scheduler) Quarantined() []*Task {
+	var tasks []*Task
+	for _, t := range s.Tasks() {
+		if t.State() == StateDisabled {
+			tasks = append(tasks, t)
+		}
+	}
+	return tasks
+}
+
+// Enable puts a disabled task back into service, with its schedule
+// restarting from now and its panic count cleared. It does nothing for a
+// task that is not disabled, and returns ErrTaskStopped for a stopped one.
+func (t *Task) Enable() error {
+	if t.State() != StateDisabled {
+		return nil
+	}
+	if t.afterFunc {
+		t.loopMu.Lock()
+		defer t.loopMu.Unlock()
+
+		if t.stopping.Load() {
+			return ErrTaskStopped
+		}
+		if t.timer == nil {
+			t.enableUnstarted()
+			return nil
+		}
+		t.enable()
+		return nil
+	}
+	if ok, err := t.unstarted(t.enableUnstarted); ok {
+		return err
+	}
+	select {
+	case t.enableChan <- struct{}{}:
+		<-t.updated
+		return nil
+	case <-t.stopChan:
+		return ErrTaskStopped
+	case <-t.done:
+		return ErrTaskStopped
+	}
+}
+
+// enableUnstarted is enable for a task whose run loop has not started.
+func (t *Task) enableUnstarted() {
+	t.disabled = false
+	t.panics = nil
+	t.state.Store(int32(StateIdle))
+}
+
+func (t *Task) enable() {
+	if !t.disabled {
+		return
+	}
+	t.disabled = false
+	t.panics = nil
+	t.log.Info("task enabled")
+	if t.events != nil {
+		t.due = time.Time{}
+		t.setState(StateWaiting, t.due)
+		return
+	}
+	t.reschedule(t.schedule)
+}
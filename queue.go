@@ -0,0 +1,56 @@
+package every
+
+import "time"
+
+// WithQueue makes a calendar task keep the fires that come due while it is
+// running, up to depth of them, and run them back to back afterwards, each
+// with its own scheduled time, instead of running once for all of them.
+// When the queue is full, overflow decides which fire to drop:
+// OverflowDropOldest makes room, while OverflowDropNewest and OverflowBlock
+// drop the latest fire, since the schedule cannot be made to wait. Each
+// drop counts as a SkipOverflow skip. Interval tasks never overlap their
+// schedule and ignore the queue.
+func WithQueue(depth int, overflow OverflowPolicy) Option {
+	return func(t *Task) {
+		t.queueDepth, t.queueFull = depth, overflow
+	}
+}
+
+// dequeue queues the fires that came due during the run for t.due that
+// ended at end, and returns the oldest queued fire, if any.
+func (t *Task) dequeue(end time.Time) (time.Time, bool) {
+	t.dequeued = false
+	if t.queueDepth <= 0 {
+		return time.Time{}, false
+	}
+	if _, ok := t.schedule.(intervalSchedule); ok {
+		return time.Time{}, false
+	}
+
+	from, dropped := t.due, 0
+	if t.queuedTo.After(from) {
+		from = t.queuedTo
+	}
+	for next := t.next(from); !next.IsZero() && !next.After(end); next = t.next(next) {
+		t.queuedTo = next
+		switch {
+		case len(t.queue) < t.queueDepth:
+			t.queue = append(t.queue, next)
+		case t.queueFull == OverflowDropOldest:
+			t.queue = append(t.queue[1:], next)
+			dropped++
+		default:
+			dropped++
+		}
+	}
+	t.skip(SkipOverflow, dropped)
+
+	if len(t.queue) == 0 {
+		t.queuedTo = time.Time{}
+		return time.Time{}, false
+	}
+	next := t.queue[0]
+	t.queue = t.queue[1:]
+	t.dequeued = true
+	return next, true
+}
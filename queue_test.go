@@ -0,0 +1,67 @@
+package every
+
+import (
+	"testing"
+	"time"
+)
+
+func TestQueue(t *testing.T) {
+	due := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	at := func(minute int) time.Time { return due.Add(time.Duration(minute) * time.Minute) }
+	end := at(4).Add(30 * time.Second)
+
+	for _, tc := range []struct {
+		name     string
+		spec     string
+		opt      Option
+		want     []time.Time
+		overflow int
+	}{
+		{"no queue", "* * * * *", nil, nil, 0},
+		{"interval", "1m", WithQueue(2, OverflowDropNewest), nil, 0},
+		{"room", "* * * * *", WithQueue(5, OverflowDropNewest), []time.Time{at(1), at(2), at(3), at(4)}, 0},
+		{"drop oldest", "* * * * *", WithQueue(2, OverflowDropOldest), []time.Time{at(3), at(4)}, 2},
+		{"drop newest", "* * * * *", WithQueue(2, OverflowDropNewest), []time.Time{at(1), at(2)}, 2},
+		{"block", "* * * * *", WithQueue(2, OverflowBlock), []time.Time{at(1), at(2)}, 2},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var opts []Option
+			if tc.opt != nil {
+				opts = append(opts, tc.opt)
+			}
+			overflow := 0
+			opts = append(opts, OnSkip(func(r SkipReason) {
+				if r == SkipOverflow {
+					overflow++
+				}
+			}))
+			task, err := NewTask(tc.spec, func() {}, opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// Each queued fire runs for a few seconds, too short for
+			// another fire to come due.
+			var got []time.Time
+			task.due = due
+			for next, ok := task.dequeue(end); ok; next, ok = task.dequeue(task.due.Add(5 * time.Second)) {
+				got = append(got, next)
+				task.due = next
+			}
+			if len(got) != len(tc.want) {
+				t.Fatalf("dequeued %v, want %v", got, tc.want)
+			}
+			for i := range got {
+				if !got[i].Equal(tc.want[i]) {
+					t.Errorf("fire %d: %v, want %v", i, got[i], tc.want[i])
+				}
+			}
+			if overflow != tc.overflow {
+				t.Errorf("%d overflow skips, want %d", overflow, tc.overflow)
+			}
+			if !task.queuedTo.IsZero() {
+				t.Errorf("queuedTo = %v after the queue drained", task.queuedTo)
+			}
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package every
+
+import "time"
+
+// Stage is one step of a ramp: fire every Every for the first For of the
+// ramp's remaining time.
+type Stage struct {
+	For   time.Duration
+	Every time.Duration
+}
+
+// WithRamp makes the task step through stages after Start before settling
+// on its own schedule, for example polling every 10s for the first 5m after
+// a deploy and then every 5m:
+//
+//	every.NewTask("5m", poll, every.WithRamp(every.Stage{For: 5 * time.Minute, Every: 10 * time.Second}))
+func WithRamp(stages ...Stage) Option {
+	return func(t *Task) {
+		t.ramp = stages
+	}
+}
+
+// scheduled returns the fire time after now from the current ramp stage,
+// or from the task's schedule once the ramp is over.
+func (t *Task) scheduled(now time.Time) time.Time {
+	elapsed := now.Sub(t.started)
+	for _, stage := range t.ramp {
+		if elapsed < stage.For {
+			return now.Add(stage.Every)
+		}
+		elapsed -= stage.For
+	}
+	if d, ok := t.schedule.(intervalSchedule); ok && t.phases > 0 {
+		return t.phased(time.Duration(d), now)
+	}
+	return t.schedule.next(now)
+}
@@ -0,0 +1,55 @@
+package every
+
+import "time"
+
+// RebasePolicy decides where the wait in progress goes when an interval
+// task's interval is changed.
+type RebasePolicy int
+
+const (
+	// RebaseFromNow restarts the wait from the time of the change, so the
+	// next fire is a full new interval away.
+	RebaseFromNow RebasePolicy = iota
+	// RebaseFromLastFire keeps the point the wait started from, so the
+	// next fire is the new interval after it, or immediately if that has
+	// already passed. Strict cadences keep their phase this way.
+	RebaseFromLastFire
+)
+
+func (p RebasePolicy) String() string {
+	switch p {
+	case RebaseFromNow:
+		return "from-now"
+	case RebaseFromLastFire:
+		return "from-last-fire"
+	}
+	return "unknown"
+}
+
+// WithRebasePolicy sets how UpdateInterval and Reschedule treat the wait in
+// progress when both the old and new schedules are intervals. Calendar
+// schedules always start afresh from now.
+func WithRebasePolicy(p RebasePolicy) Option {
+	return func(t *Task) {
+		t.rebase = p
+	}
+}
+
+// rebased returns the pending fire moved from the old interval to the
+// current one, if the rebase policy asks for it.
+func (t *Task) rebased(old schedule, now time.Time) (time.Time, bool) {
+	if t.rebase != RebaseFromLastFire || t.due.IsZero() {
+		return time.Time{}, false
+	}
+	from, ok := old.(intervalSchedule)
+	to, ok2 := t.schedule.(intervalSchedule)
+	if !ok || !ok2 {
+		return time.Time{}, false
+	}
+
+	due := t.due.Add(time.Duration(to - from))
+	if due.Before(now) {
+		due = now
+	}
+	return due, true
+}
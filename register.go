@@ -0,0 +1,84 @@
+package every
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CustomSchedule is a schedule from a ScheduleParser. A Schedule from
+// ParseSchedule is one, so a parser can translate its syntax into the
+// package's own.
+type CustomSchedule interface {
+	// Next returns the first fire time after t, or the zero time if the
+	// schedule has ended.
+	Next(t time.Time) time.Time
+	// String returns the spec the schedule was parsed from, or one that
+	// parses to the same schedule.
+	String() string
+}
+
+// ScheduleParser parses specs in a custom schedule syntax.
+type ScheduleParser func(spec string) (CustomSchedule, error)
+
+var (
+	parsersMu sync.RWMutex
+	parsers   = map[string]ScheduleParser{}
+	prefixes  []string
+)
+
+// RegisterScheduleParser makes specs starting with prefix, such as
+// "fiscal:", parse with p wherever a schedule is accepted: NewTask,
+// Reschedule, unions and so on. p is given the whole spec. Custom
+// schedules are calendar schedules: they are checked against the wall
+// clock and subject to misfire policies. Where prefixes overlap the
+// longest one wins. It panics if prefix is empty or already registered,
+// and is meant to be called from init.
+func RegisterScheduleParser(prefix string, p ScheduleParser) {
+	parsersMu.Lock()
+	defer parsersMu.Unlock()
+
+	if prefix == "" || p == nil {
+		panic("every: RegisterScheduleParser with empty prefix or nil parser")
+	}
+	if _, dup := parsers[prefix]; dup {
+		panic("every: RegisterScheduleParser called twice for " + prefix)
+	}
+	parsers[prefix] = p
+	prefixes = append(prefixes, prefix)
+	sort.Slice(prefixes, func(i, j int) bool { return len(prefixes[i]) > len(prefixes[j]) })
+}
+
+// registeredParser returns the registered parser for spec, if any.
+func registeredParser(spec string) (ScheduleParser, bool) {
+	parsersMu.RLock()
+	defer parsersMu.RUnlock()
+
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(spec, prefix) {
+			return parsers[prefix], true
+		}
+	}
+	return nil, false
+}
+
+// customSchedule adapts a CustomSchedule to the package's own interface.
+type customSchedule struct {
+	CustomSchedule
+}
+
+func (s customSchedule) next(t time.Time) time.Time {
+	return s.Next(t)
+}
+
+func parseCustom(p ScheduleParser, spec string) (schedule, error) {
+	s, err := p(spec)
+	if err != nil {
+		return nil, err
+	}
+	if parsed, ok := s.(Schedule); ok {
+		return parsed.s, nil
+	}
+	return customSchedule{s}, nil
+}
@@ -0,0 +1,26 @@
+package every
+
+import "time"
+
+// Remaining returns how long until the task next fires, rounded up to the
+// second, for countdowns and progress bars. It is zero while the task is
+// running, or when it will not fire: it is stopped, disabled, paused on
+// its own or with its Namespace, standing by, or its schedule has ended. It
+// reads only atomics, so polling it from a UI loop is cheap.
+func (t *Task) Remaining() time.Duration {
+	if t.State() != StateWaiting || t.Paused() {
+		return 0
+	}
+	if sb := t.standby.Load(); sb != nil && !sb.primary() {
+		return 0
+	}
+	next := t.nextRun.Load()
+	if next.IsZero() {
+		return 0
+	}
+	d := time.Until(next)
+	if d <= 0 {
+		return 0
+	}
+	return (d + time.Second - 1).Truncate(time.Second)
+}
@@ -0,0 +1,75 @@
+package every
+
+// Resources describes what one run of a task uses, for a Scheduler's
+// ResourceLimits.
+type Resources struct {
+	CPU      bool // the run is CPU-heavy
+	IO       bool // the run is IO-heavy
+	MemoryMB int  // the run needs about this much memory
+}
+
+// ResourceLimits caps what the Scheduler's concurrent runs may use
+// together, regardless of the pool size: at most CPU CPU-heavy runs, IO
+// IO-heavy runs and MemoryMB of declared memory. Zero means no limit. A run
+// declaring more memory than the limit may still run on its own.
+type ResourceLimits struct {
+	CPU      int
+	IO       int
+	MemoryMB int
+}
+
+// WithResources declares the resources the task's runs use.
+func WithResources(r Resources) Option {
+	return func(t *Task) {
+		t.resources = r
+	}
+}
+
+// SetResourceLimits limits the Scheduler's concurrent runs by the
+// Resources their tasks declare. It sets up a worker pool if there is none
+// yet, without a limit on its size, and like SetPoolSize must be called
+// before the tasks are started.
+func (s *Scheduler) SetResourceLimits(l ResourceLimits) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.limits = l
+	s.resetPoolLocked()
+}
+
+// fits reports whether a run of t would stay within the pool's limits.
+func (p *pool) fits(t *Task) bool {
+	r, l := t.resources, p.limits
+	switch {
+	case r.CPU && l.CPU > 0 && p.cpu >= l.CPU:
+		return false
+	case r.IO && l.IO > 0 && p.io >= l.IO:
+		return false
+	case r.MemoryMB > 0 && l.MemoryMB > 0 && p.memory > 0 && p.memory+r.MemoryMB > l.MemoryMB:
+		return false
+	}
+	return true
+}
+
+// take gives t a slot and its resources.
+func (p *pool) take(t *Task) {
+	p.free--
+	p.cpu += boolInt(t.resources.CPU)
+	p.io += boolInt(t.resources.IO)
+	p.memory += t.resources.MemoryMB
+}
+
+// giveBack returns what take gave t.
+func (p *pool) giveBack(t *Task) {
+	p.free++
+	p.cpu -= boolInt(t.resources.CPU)
+	p.io -= boolInt(t.resources.IO)
+	p.memory -= t.resources.MemoryMB
+}
+
+func boolInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
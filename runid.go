@@ -0,0 +1,78 @@
+package every
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+)
+
+// RunIDHeader is the HTTP header RunIDTransport stamps requests with.
+const RunIDHeader = "X-Run-ID"
+
+var (
+	// runPrefix makes run IDs unique across processes; runSeq across the
+	// runs of all tasks in this one.
+	runPrefix = newRunPrefix()
+	runSeq    atomic.Uint64
+)
+
+func newRunPrefix() string {
+	b := make([]byte, 6)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// RunID returns an identifier unique to this execution of any task in any
+// process, for tying together the logs and requests of one run across
+// services.
+func (r RunInfo) RunID() string {
+	if r.seq == 0 {
+		return ""
+	}
+	return runPrefix + "-" + strconv.FormatUint(r.seq, 36)
+}
+
+// RunIDFrom returns the run ID of the run ctx belongs to, or "" outside a
+// task run.
+func RunIDFrom(ctx context.Context) string {
+	info, _ := RunInfoFrom(ctx)
+	return info.RunID()
+}
+
+// LoggerFrom returns the logger of the task the run ctx belongs to, with
+// the run ID attached as "run_id". Outside a task run it returns
+// slog.Default().
+func LoggerFrom(ctx context.Context) *slog.Logger {
+	r, ok := runFrom(ctx)
+	if !ok {
+		return slog.Default()
+	}
+	return r.task.Logger().With("run_id", r.info.RunID())
+}
+
+// RunIDTransport wraps base, or http.DefaultTransport if it is nil, so that
+// requests made with a run's context carry its run ID in RunIDHeader.
+func RunIDTransport(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return runIDTransport{base}
+}
+
+type runIDTransport struct {
+	base http.RoundTripper
+}
+
+func (t runIDTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	id := RunIDFrom(req.Context())
+	if id == "" || req.Header.Get(RunIDHeader) != "" {
+		return t.base.RoundTrip(req)
+	}
+	req = req.Clone(req.Context())
+	req.Header.Set(RunIDHeader, id)
+	return t.base.RoundTrip(req)
+}
@@ -0,0 +1,71 @@
+package every
+
+import (
+	"context"
+	"time"
+)
+
+// RunInfo describes one execution of a task.
+type RunInfo struct {
+	// ID numbers the task's runs from 1.
+	ID uint64
+	// Attempt is 1 for the first try at a fire and counts up on retries.
+	Attempt int
+	// Scheduled is when the fire was due; Fired is when it started.
+	Scheduled time.Time
+	Fired     time.Time
+	// Previous is when the task's previous run was due, or zero for the
+	// first run.
+	Previous time.Time
+	// CatchUp is set for runs replaying a missed fire under
+	// MisfireCatchUp.
+	CatchUp bool
+	// Sequence numbers the task's fires from 1, counting those that were
+	// skipped, so downstream consumers can tell missed executions from a
+	// gap between the Sequence of consecutive runs. Missed is the size of
+	// the gap before this run.
+	Sequence uint64
+	Missed   int
+
+	seq uint64
+}
+
+type run struct {
+	task *Task
+	info RunInfo
+	// last is set for the run of a one-shot task, which closes Done once
+	// it is over.
+	last bool
+}
+
+func runFrom(ctx context.Context) (*run, bool) {
+	r, ok := ctx.Value(taskKey{}).(*run)
+	return r, ok
+}
+
+// RunInfoFrom returns the metadata of the run ctx belongs to. Like the run
+// context itself, it is only meaningful until the task func returns.
+func RunInfoFrom(ctx context.Context) (RunInfo, bool) {
+	r, ok := runFrom(ctx)
+	if !ok {
+		return RunInfo{}, false
+	}
+	return r.info, true
+}
+
+// FireInfo tells a task which fire it is handling, so jobs that process a
+// time range can use [Previous, Scheduled) instead of guessing from now.
+type FireInfo struct {
+	Scheduled time.Time
+	Actual    time.Time
+	Previous  time.Time
+	CatchUp   bool
+}
+
+// NewFireTask is like NewTask for funcs that take the FireInfo of each fire.
+func NewFireTask(interval string, task func(fire FireInfo), opts ...Option) (*Task, error) {
+	return NewContextTask(interval, func(ctx context.Context) {
+		info, _ := RunInfoFrom(ctx)
+		task(FireInfo{Scheduled: info.Scheduled, Actual: info.Fired, Previous: info.Previous, CatchUp: info.CatchUp})
+	}, opts...)
+}
@@ -0,0 +1,158 @@
+package every
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// wakeCheck bounds how long a calendar schedule sleeps before comparing
+// the wall clock against its next fire time again. Timers run on the
+// monotonic clock, which does not advance while the machine is suspended
+// and ignores NTP steps, so without rechecking a "daily@03:00" task could
+// fire hours late after a resume.
+const wakeCheck = time.Minute
+
+type schedule interface {
+	next(t time.Time) time.Time
+	String() string
+}
+
+type intervalSchedule time.Duration
+
+func (s intervalSchedule) next(t time.Time) time.Time {
+	return t.Add(time.Duration(s))
+}
+
+func (s intervalSchedule) String() string {
+	return formatDuration(time.Duration(s))
+}
+
+type dailySchedule struct {
+	hour, minute int
+}
+
+func (s dailySchedule) next(t time.Time) time.Time {
+	n := time.Date(t.Year(), t.Month(), t.Day(), s.hour, s.minute, 0, 0, t.Location())
+	if !n.After(t) {
+		n = time.Date(t.Year(), t.Month(), t.Day()+1, s.hour, s.minute, 0, 0, t.Location())
+	}
+	return n
+}
+
+func (s dailySchedule) String() string {
+	return fmt.Sprintf("daily@%02d:%02d", s.hour, s.minute)
+}
+
+var descriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+type parser struct {
+	seconds     bool
+	strict      bool
+	coords      *coordinates
+	minInterval time.Duration
+}
+
+func parseSchedule(spec string) (schedule, error) {
+	return parser{}.parse(spec)
+}
+
+// businessSchedule fires daily at a time of day, but only on business
+// days; weekends and holidays are handled by the task's roll policy.
+type businessSchedule struct {
+	dailySchedule
+}
+
+func (s businessSchedule) String() string {
+	return fmt.Sprintf("business-daily@%02d:%02d", s.hour, s.minute)
+}
+
+func parseClock(spec, clock string) (dailySchedule, error) {
+	at, err := time.Parse("15:04", clock)
+	if err != nil {
+		return dailySchedule{}, fmt.Errorf("%w: time %s", ErrInvalidValue, spec)
+	}
+	return dailySchedule{hour: at.Hour(), minute: at.Minute()}, nil
+}
+
+func (p parser) parse(spec string) (schedule, error) {
+	switch trimmed := strings.TrimSpace(spec); {
+	case !p.strict:
+		spec = trimmed
+	case trimmed != spec:
+		return nil, fmt.Errorf("%w: whitespace around schedule %q", ErrInvalidValue, spec)
+	}
+	if strings.Contains(spec, "|") {
+		return p.parseUnion(spec)
+	}
+	if custom, ok := registeredParser(spec); ok {
+		return parseCustom(custom, spec)
+	}
+	if clock, ok := strings.CutPrefix(spec, "business-daily@"); ok {
+		daily, err := parseClock(spec, clock)
+		if err != nil {
+			return nil, err
+		}
+		return businessSchedule{daily}, nil
+	}
+	if strings.HasPrefix(spec, "@") {
+		return p.descriptor(spec)
+	}
+	if strings.HasPrefix(spec, "next ") {
+		return parseBoundary(spec)
+	}
+	if strings.ContainsAny(spec, " \t") {
+		return parseCron(spec, p.seconds)
+	}
+	if clock, ok := strings.CutPrefix(spec, "daily@"); ok {
+		return parseClock(spec, clock)
+	}
+	if at, ok := strings.CutPrefix(spec, "at@"); ok {
+		return parseOnce(spec, at)
+	}
+	if every, anchor, ok := strings.Cut(spec, "@"); ok {
+		return p.parseAnchored(spec, every, anchor)
+	}
+	if s, ok, err := parseSun(spec, p.coords); ok {
+		return s, err
+	}
+
+	return p.interval(spec)
+}
+
+// interval parses an interval schedule, rejecting intervals short enough
+// to keep the run loop spinning.
+func (p parser) interval(spec string) (intervalSchedule, error) {
+	d, err := p.duration(spec)
+	if err != nil {
+		return 0, err
+	}
+	minimum := p.minInterval
+	if minimum <= 0 {
+		minimum = defaultMinInterval
+	}
+	if d < minimum {
+		return 0, fmt.Errorf("%w: interval %s is below the minimum of %s", ErrInvalidValue, spec, formatDuration(minimum))
+	}
+	return intervalSchedule(d), nil
+}
+
+func (p parser) descriptor(spec string) (schedule, error) {
+	if interval, ok := strings.CutPrefix(spec, "@every "); ok {
+		return p.interval(strings.TrimSpace(interval))
+	}
+
+	cron, ok := descriptors[spec]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown descriptor %s", ErrInvalidSchedule, spec)
+	}
+	return parseCron(cron, false)
+}
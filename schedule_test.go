@@ -0,0 +1,54 @@
+package every
+
+import (
+	"testing"
+	"time"
+)
+
+func FuzzParseInterval(f *testing.F) {
+	for _, s := range []string{"5m", "1h30m", " 2d ", "30m1h", "90s", "5", "5w", "1h1h", "9223372036854775807s", ""} {
+		f.Add(s, false)
+		f.Add(s, true)
+	}
+	f.Fuzz(func(t *testing.T, in string, strict bool) {
+		d, err := parser{strict: strict}.duration(in)
+		if err != nil {
+			if _, ok := err.(*ParseError); !ok {
+				t.Fatalf("duration(%q) error %T is not a *ParseError", in, err)
+			}
+			return
+		}
+		if d < 0 {
+			t.Fatalf("duration(%q) = %v, negative", in, d)
+		}
+		back, err := parser{strict: true}.duration(formatDuration(d))
+		if err != nil || back != d {
+			t.Fatalf("duration(%q) = %v, which formats as %q and parses back as %v, %v", in, d, formatDuration(d), back, err)
+		}
+	})
+}
+
+func FuzzParseSchedule(f *testing.F) {
+	for _, s := range []string{
+		"5m", "daily@06:30", "at@2024-01-01T00:00:00Z", "0 */5 * * *", "@hourly",
+		"next monday", "business-daily@09:00", "1h@00:15", "3d@06:00", "sunrise",
+		"5m|daily@06:00", "1h 30m", "*/5 * * * * *",
+	} {
+		f.Add(s)
+	}
+	from := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+	f.Fuzz(func(t *testing.T, spec string) {
+		s, err := ParseSchedule(spec)
+		if err != nil {
+			return
+		}
+		s.Next(from)
+		again, err := ParseSchedule(s.String())
+		if err != nil {
+			t.Fatalf("ParseSchedule(%q) formats as %q, which does not parse: %v", spec, s, err)
+		}
+		if again.String() != s.String() {
+			t.Fatalf("ParseSchedule(%q) formats as %q, then as %q", spec, s, again)
+		}
+	})
+}
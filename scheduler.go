@@ -0,0 +1,115 @@
+package every
+
+import (
+	"log/slog"
+	"sync"
+	"time"
+)
+
+type TaskID uint64
+
+type Scheduler struct {
+	mu       sync.Mutex
+	tasks    []*Task
+	lastID   TaskID
+	logger   *slog.Logger
+	pool     *pool
+	poolSize int
+	limits   ResourceLimits
+	sv       *Supervisor
+	ns       map[string]*Namespace
+	defaults []Option
+	running  bool
+	fences   map[string]*fence
+	standby  *standby
+	coalesce time.Duration
+}
+
+func NewScheduler() *Scheduler {
+	return &Scheduler{}
+}
+
+// Add registers t and returns its ID, which stays the same for the life of
+// the Scheduler regardless of the task's name. IDs count up from 1 in
+// registration order.
+func (s *Scheduler) Add(t *Task) TaskID {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.addLocked(t)
+}
+
+func (s *Scheduler) addLocked(t *Task) TaskID {
+	if len(s.defaults) > 0 {
+		t.inherit(s.defaults)
+	}
+	if t.logger == nil {
+		t.logger = s.logger
+	}
+	s.lastID++
+	t.id = s.lastID
+	t.pool = s.pool
+	t.coalesce = s.coalesce
+	t.fences = s.fencesLocked(t.fenceNames)
+	if s.standby != nil {
+		t.standby.Store(s.standby)
+	}
+	if t.supervisor == nil {
+		t.supervisor = s.sv
+	}
+	s.tasks = append(s.tasks, t)
+	return t.id
+}
+
+func (s *Scheduler) Task(id TaskID) (*Task, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, t := range s.tasks {
+		if t.id == id {
+			return t, true
+		}
+	}
+	return nil, false
+}
+
+func (s *Scheduler) Tasks() []*Task {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]*Task(nil), s.tasks...)
+}
+
+func (s *Scheduler) StartAll() {
+	s.mu.Lock()
+	s.running = true
+	s.mu.Unlock()
+
+	for _, t := range s.Tasks() {
+		t.Start()
+	}
+}
+
+// StopAll stops every task. Under Standby, the lease is given up once they
+// have stopped.
+func (s *Scheduler) StopAll() {
+	s.mu.Lock()
+	sb := s.standby
+	s.mu.Unlock()
+	if sb != nil {
+		sb.leave()
+	}
+	s.stopTasks()
+}
+
+func (s *Scheduler) stopTasks() {
+	var wg sync.WaitGroup
+	for _, t := range s.Tasks() {
+		wg.Add(1)
+		go func(t *Task) {
+			defer wg.Done()
+			t.Stop()
+		}(t)
+	}
+	wg.Wait()
+}
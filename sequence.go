@@ -0,0 +1,19 @@
+package every
+
+import "time"
+
+// sequence numbers the run described by info among the task's fires and
+// reports any gap since the previous run.
+func (t *Task) sequence(info *RunInfo) {
+	t.fires++
+	info.Sequence = t.fires
+	info.Missed = int(t.fires - t.lastRunFire - 1)
+	t.lastRunFire = t.fires
+	if info.Missed == 0 {
+		return
+	}
+	t.stats.gaps.Add(1)
+	if t.notifiers != nil {
+		t.notify(Event{Type: EventGap, Time: time.Now(), Run: info.ID, Sequence: info.Sequence, Missed: info.Missed})
+	}
+}
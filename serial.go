@@ -0,0 +1,76 @@
+package every
+
+import (
+	"context"
+	"sync"
+)
+
+// SetSerial makes the namespace run its tasks strictly one at a time, in
+// the order they fire: a fire that finds another of the namespace's tasks
+// running joins a single queue and waits its turn behind the fires that
+// joined before it. This suits tasks sharing a resource that allows no
+// concurrency, such as a SQLite database taking an exclusive lock. Like
+// SetQuota, it must be called before the tasks start.
+func (n *Namespace) SetSerial(serial bool) {
+	n.s.mu.Lock()
+	defer n.s.mu.Unlock()
+
+	n.serial = nil
+	if serial {
+		n.serial = &serialQueue{}
+	}
+}
+
+// serialQueue hands out turns to run one at a time, first come first
+// served.
+type serialQueue struct {
+	mu      sync.Mutex
+	busy    bool
+	waiting []chan struct{}
+}
+
+// wait blocks until it is the caller's turn, returning false if ctx is
+// done first.
+func (q *serialQueue) wait(ctx context.Context) bool {
+	q.mu.Lock()
+	if !q.busy {
+		q.busy = true
+		q.mu.Unlock()
+		return true
+	}
+	ready := make(chan struct{})
+	q.waiting = append(q.waiting, ready)
+	q.mu.Unlock()
+
+	select {
+	case <-ready:
+		return true
+	case <-ctx.Done():
+	}
+	q.mu.Lock()
+	for i, v := range q.waiting {
+		if v == ready {
+			q.waiting = append(q.waiting[:i], q.waiting[i+1:]...)
+			q.mu.Unlock()
+			return false
+		}
+	}
+	q.mu.Unlock()
+	// The turn came as ctx was done; pass it on.
+	q.done()
+	return false
+}
+
+// done ends the current turn, starting the next one if a fire is waiting.
+func (q *serialQueue) done() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if len(q.waiting) == 0 {
+		q.busy = false
+		return
+	}
+	close(q.waiting[0])
+	q.waiting[0] = nil
+	q.waiting = q.waiting[1:]
+}
@@ -0,0 +1,84 @@
+package every
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestSerialQueue(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		waiters int
+		cancel  []int
+		want    []int
+	}{
+		{"one waiter", 1, nil, []int{0}},
+		{"fire order", 4, nil, []int{0, 1, 2, 3}},
+		{"cancelled waiter", 4, []int{1}, []int{0, 2, 3}},
+		{"all cancelled", 2, []int{0, 1}, nil},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ns := NewScheduler().Namespace("db")
+			ns.SetSerial(true)
+			if !ns.acquire(context.Background()) {
+				t.Fatal("first acquire failed")
+			}
+
+			turns := make(chan int, tc.waiters)
+			cancels := make([]context.CancelFunc, tc.waiters)
+			for i := range tc.waiters {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancels[i] = cancel
+				defer cancel()
+				go func() {
+					if ns.acquire(ctx) {
+						turns <- i
+						ns.release()
+					}
+				}()
+				// Join the queue in order.
+				for {
+					ns.serial.mu.Lock()
+					n := len(ns.serial.waiting)
+					ns.serial.mu.Unlock()
+					if n == i+1 {
+						break
+					}
+					time.Sleep(time.Millisecond)
+				}
+			}
+			for _, i := range tc.cancel {
+				cancels[i]()
+			}
+			for {
+				ns.serial.mu.Lock()
+				n := len(ns.serial.waiting)
+				ns.serial.mu.Unlock()
+				if n == tc.waiters-len(tc.cancel) {
+					break
+				}
+				time.Sleep(time.Millisecond)
+			}
+			ns.release()
+
+			for _, want := range tc.want {
+				select {
+				case got := <-turns:
+					if got != want {
+						t.Fatalf("turn went to fire %d, want %d", got, want)
+					}
+				case <-time.After(time.Second):
+					t.Fatalf("fire %d never got its turn", want)
+				}
+			}
+			if !ns.acquire(context.Background()) {
+				t.Fatal("acquire failed once the queue drained")
+			}
+			ns.release()
+			if ns.serial.busy {
+				t.Error("queue still busy after the last turn")
+			}
+		})
+	}
+}
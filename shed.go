@@ -0,0 +1,30 @@
+package every
+
+import "runtime"
+
+// WithLoadShedding marks the task as best effort: a fire is skipped, as
+// SkipOverload, whenever probe returns more than threshold, so background
+// work backs off while the process is under stress. A nil probe measures
+// runtime.NumGoroutine.
+func WithLoadShedding(probe func() float64, threshold float64) Option {
+	if probe == nil {
+		probe = GoroutineLoad
+	}
+	return func(t *Task) {
+		t.shedLoad, t.shedAbove = probe, threshold
+	}
+}
+
+// GoroutineLoad is a load probe returning the number of goroutines.
+func GoroutineLoad() float64 {
+	return float64(runtime.NumGoroutine())
+}
+
+func (t *Task) overloaded() bool {
+	load := t.shedLoad()
+	if load <= t.shedAbove {
+		return false
+	}
+	t.log.Debug("shedding load", "load", load, "threshold", t.shedAbove)
+	return true
+}
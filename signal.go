@@ -0,0 +1,28 @@
+package every
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// HandleSignals blocks until SIGINT or SIGTERM is received and then stops
+// every task, returning the signal that caused the shutdown. Each SIGHUP
+// calls reload, which may be nil.
+func (s *Scheduler) HandleSignals(reload func()) os.Signal {
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM, syscall.SIGHUP)
+	defer signal.Stop(sigChan)
+
+	for sig := range sigChan {
+		if sig == syscall.SIGHUP {
+			if reload != nil {
+				reload()
+			}
+			continue
+		}
+		s.StopAll()
+		return sig
+	}
+	return nil
+}
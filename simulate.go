@@ -0,0 +1,73 @@
+package every
+
+import (
+	"context"
+	"time"
+)
+
+// Simulate runs the Scheduler's tasks through virtual time from from until
+// until, without starting them, so days of scheduling can be checked in
+// seconds. Fires happen in time order, ties in registration order, each
+// run taking no virtual time. Virtual time passes speed times faster than
+// real time; a speed of zero or less runs the fires back to back.
+//
+// Task funcs see the virtual fire time as the Scheduled and Fired times
+// of RunInfoFrom. Conditions are honoured, but not misfire policies,
+// jitter, retries or timeouts. Triggered tasks such as debouncers never
+// fire, and nor do disabled tasks or tasks without a func. Failures are
+// logged. Simulate returns ctx's error if it is cancelled first.
+func (s *Scheduler) Simulate(ctx context.Context, from, until time.Time, speed float64) error {
+	type sim struct {
+		task, planner *Task
+		next, prev    time.Time
+		runs          uint64
+	}
+	var sims []*sim
+	for _, t := range s.Tasks() {
+		if t.events != nil || t.taskFunc == nil || t.State() == StateDisabled {
+			continue
+		}
+		p := t.planner()
+		if next, _ := p.first(from); !next.IsZero() {
+			sims = append(sims, &sim{task: t, planner: p, next: next})
+		}
+	}
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	started := time.Now()
+	for {
+		var due *sim
+		for _, c := range sims {
+			if !c.next.IsZero() && (due == nil || c.next.Before(due.next)) {
+				due = c
+			}
+		}
+		if due == nil || due.next.After(until) {
+			return nil
+		}
+
+		if speed > 0 {
+			at := started.Add(time.Duration(float64(due.next.Sub(from)) / speed))
+			timer.Reset(time.Until(at))
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		} else if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		t := due.task
+		if t.condition == nil || t.condition() {
+			due.runs++
+			r := &run{task: t, info: RunInfo{ID: due.runs, Attempt: 1, Scheduled: due.next, Fired: due.next, Previous: due.prev, seq: runSeq.Add(1)}}
+			if err := t.taskFunc(context.WithValue(ctx, taskKey{}, r)); err != nil {
+				t.Logger().Error("task failed", "error", err, "scheduled", due.next)
+			}
+			due.prev = due.next
+		}
+		due.next = due.planner.next(due.next)
+	}
+}
@@ -0,0 +1,35 @@
+package every
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestSimulateSkipsTasksWithoutFunc(t *testing.T) {
+	s := NewScheduler()
+	var runs int
+	live, _ := NewTask("1h", func() { runs++ })
+	empty, err := NewTask("1h", nil, WithMissingFunc(MissingFuncSkip))
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.Add(live)
+	s.Add(empty)
+	defs := []TaskDefinition{{Name: "gone", Func: "gone", Schedule: "1h"}}
+	if _, err := s.Load(defs, Registry{}); err == nil {
+		t.Fatal("Load with a missing func succeeded under MissingFuncFail")
+	}
+	s.SetDefaults(WithMissingFunc(MissingFuncQuarantine))
+	if _, err := s.Load(defs, Registry{}); err != nil {
+		t.Fatal(err)
+	}
+
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	if err := s.Simulate(context.Background(), from, from.Add(3*time.Hour), 0); err != nil {
+		t.Fatal(err)
+	}
+	if runs != 3 {
+		t.Errorf("runs = %d, want 3", runs)
+	}
+}
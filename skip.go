@@ -0,0 +1,96 @@
+package every
+
+import "time"
+
+type SkipReason int
+
+const (
+	// SkipMisfire means a calendar fire was missed and dropped by the
+	// misfire policy.
+	SkipMisfire SkipReason = iota
+	// SkipDayOff means a fire fell on a holiday or weekend and was dropped
+	// by the roll policy.
+	SkipDayOff
+	// SkipPaused means the task or its Namespace was paused.
+	SkipPaused
+	// SkipCondition means the task's WithCondition predicate returned
+	// false.
+	SkipCondition
+	// SkipOverload means the task sheds load and the load probe was over
+	// its threshold.
+	SkipOverload
+	// SkipOverflow means a fire that came due during a run was dropped
+	// because the WithQueue queue was full.
+	SkipOverflow
+	// SkipFenced means another task in one of the task's WithFence groups
+	// was running and the fence was set to skip.
+	SkipFenced
+	// SkipStandby means the task's Scheduler is running as a standby and
+	// does not hold the lease.
+	SkipStandby
+	// SkipDelivered means the task's delivery record showed the fire as
+	// already run, or in doubt under AtMostOnce.
+	SkipDelivered
+	// SkipNoFunc means the task has no func and its MissingFuncPolicy is
+	// MissingFuncSkip.
+	SkipNoFunc
+	// SkipCircuitOpen means the task's WithCircuitBreaker circuit was
+	// open after repeated failures.
+	SkipCircuitOpen
+)
+
+func (r SkipReason) String() string {
+	switch r {
+	case SkipMisfire:
+		return "misfire"
+	case SkipDayOff:
+		return "day-off"
+	case SkipPaused:
+		return "paused"
+	case SkipCondition:
+		return "condition"
+	case SkipOverload:
+		return "overload"
+	case SkipOverflow:
+		return "overflow"
+	case SkipFenced:
+		return "fenced"
+	case SkipStandby:
+		return "standby"
+	case SkipDelivered:
+		return "delivered"
+	case SkipNoFunc:
+		return "no-func"
+	case SkipCircuitOpen:
+		return "circuit-open"
+	}
+	return "unknown"
+}
+
+// OnSkip registers fn to be called from the task's run loop whenever a fire
+// is suppressed instead of run.
+func OnSkip(fn func(reason SkipReason)) Option {
+	return func(t *Task) {
+		t.onSkip = fn
+	}
+}
+
+func (t *Task) skip(reason SkipReason, n int) {
+	if n <= 0 {
+		return
+	}
+
+	t.stats.skips.Add(int64(n))
+	t.fires += uint64(n)
+	if t.traceSize > 0 {
+		t.decide(Decision{Kind: DecisionSkip, Reason: reason.String(), Count: n})
+	}
+
+	t.Logger().Debug("task skipped", "reason", reason, "count", n)
+	for i := 0; i < n && t.onSkip != nil; i++ {
+		t.onSkip(reason)
+	}
+	if t.notifiers != nil {
+		t.notify(Event{Type: EventSkip, Time: time.Now(), Sequence: t.fires, Reason: reason})
+	}
+}
@@ -0,0 +1,121 @@
+package every
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+type State int
+
+const (
+	StateIdle State = iota
+	StateWaiting
+	StateRunning
+	StateStopped
+	// StateDisabled means the task was taken out of service by a policy
+	// such as WithPanicLimit; it does not fire until re-enabled.
+	StateDisabled
+)
+
+func (s State) String() string {
+	switch s {
+	case StateIdle:
+		return "idle"
+	case StateWaiting:
+		return "waiting"
+	case StateRunning:
+		return "running"
+	case StateStopped:
+		return "stopped"
+	case StateDisabled:
+		return "disabled"
+	}
+	return "unknown"
+}
+
+func (s State) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+func (s *State) UnmarshalText(text []byte) error {
+	for v := StateIdle; v <= StateDisabled; v++ {
+		if v.String() == string(text) {
+			*s = v
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: state %s", ErrInvalidValue, text)
+}
+
+type TaskSnapshot struct {
+	ID       TaskID     `json:"id"`
+	Name     string     `json:"name"`
+	Tags     []string   `json:"tags,omitempty"`
+	Schedule string     `json:"schedule"`
+	State    State      `json:"state"`
+	Paused   bool       `json:"paused,omitempty"`
+	NextRun  time.Time  `json:"next_run"`
+	LastRun  time.Time  `json:"last_run"`
+	Stats    Stats      `json:"stats"`
+	Progress Progress   `json:"progress"`
+	Trace    []Decision `json:"trace,omitempty"`
+}
+
+func (t *Task) State() State {
+	return State(t.state.Load())
+}
+
+func (t *Task) Snapshot() TaskSnapshot {
+	return TaskSnapshot{
+		ID:       t.id,
+		Name:     t.name,
+		Tags:     t.Tags(),
+		Schedule: t.currentSchedule().String(),
+		State:    t.State(),
+		Paused:   t.Paused(),
+		NextRun:  t.nextRun.Load(),
+		LastRun:  t.lastRun.Load(),
+		Stats:    t.Stats(),
+		Progress: t.Progress(),
+		Trace:    t.Trace(),
+	}
+}
+
+func (s *Scheduler) Snapshot() []TaskSnapshot {
+	var snapshots []TaskSnapshot
+	for _, t := range s.Tasks() {
+		snapshots = append(snapshots, t.Snapshot())
+	}
+	return snapshots
+}
+
+func (t *Task) setState(state State, next time.Time) {
+	if state == StateWaiting && t.disabled {
+		state, next = StateDisabled, time.Time{}
+	}
+	t.state.Store(int32(state))
+	t.nextRun.Store(next)
+}
+
+// String describes the task for logs, such as
+// "task cleanup (every 5m, next 14:03:22)".
+func (t *Task) String() string {
+	s := t.currentSchedule()
+	var b strings.Builder
+	b.WriteString("task")
+	if t.name != "" {
+		b.WriteString(" " + t.name)
+	}
+
+	b.WriteString(" (")
+	if _, ok := s.(intervalSchedule); ok {
+		b.WriteString("every ")
+	}
+	b.WriteString(s.String())
+	if next := t.nextRun.Load(); !next.IsZero() {
+		b.WriteString(", next " + next.Format(time.TimeOnly))
+	}
+	b.WriteString(")")
+	return b.String()
+}
@@ -0,0 +1,54 @@
+package every
+
+import (
+	"hash/fnv"
+	"time"
+)
+
+// WithSplay delays fires by an offset within window derived from key, such
+// as a hostname or pod name. The offset is the same on every restart, so a
+// fleet sharing a schedule spreads out deterministically instead of firing
+// at once. Interval tasks only offset their first fire, which keeps their
+// phase; calendar tasks offset every fire.
+func WithSplay(key string, window time.Duration) Option {
+	return func(t *Task) {
+		t.splay = splayOffset(key, window)
+	}
+}
+
+func splayOffset(key string, window time.Duration) time.Duration {
+	if window <= 0 {
+		return 0
+	}
+	h := fnv.New64a()
+	h.Write([]byte(key))
+	return time.Duration(h.Sum64() % uint64(window))
+}
+
+// first returns the first fire time after the task starts at now, and how
+// many fires were dropped for falling on days off.
+func (t *Task) first(now time.Time) (time.Time, int) {
+	next, dropped := t.nextCounted(now)
+	if _, ok := t.schedule.(intervalSchedule); ok {
+		return next.Add(t.splay), dropped
+	}
+	return next, dropped
+}
+
+// next returns the fire time following now.
+func (t *Task) next(now time.Time) time.Time {
+	next, _ := t.nextCounted(now)
+	return next
+}
+
+func (t *Task) nextCounted(now time.Time) (time.Time, int) {
+	if _, ok := t.schedule.(intervalSchedule); ok || t.splay == 0 {
+		return t.nextWorking(now)
+	}
+
+	next, dropped := t.nextWorking(now.Add(-t.splay))
+	if next.IsZero() {
+		return next, dropped
+	}
+	return next.Add(t.splay), dropped
+}
@@ -0,0 +1,121 @@
+package every
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Elector hands a lease to one holder at a time, as a distributed lock or
+// leader election does.
+type Elector interface {
+	// Acquire takes the lease for id for ttl, or extends it if id already
+	// holds it, and reports whether id holds it.
+	Acquire(ctx context.Context, id string, ttl time.Duration) (bool, error)
+	// Release gives up the lease if id holds it.
+	Release(ctx context.Context, id string) error
+}
+
+// standby gates a Scheduler's runs on holding a lease.
+type standby struct {
+	until    atomic.Int64
+	quit     chan struct{}
+	quitOnce sync.Once
+	left     chan struct{}
+}
+
+func (sb *standby) primary() bool {
+	return time.Now().UnixNano() < sb.until.Load()
+}
+
+// leave has Standby stop the tasks and give up the lease, and waits until
+// it has.
+func (sb *standby) leave() {
+	sb.quitOnce.Do(func() { close(sb.quit) })
+	<-sb.left
+}
+
+// Standby runs the Scheduler as one of a primary/standby pair, or group,
+// sharing e, in place of StartAll. Every member starts its tasks and keeps
+// their schedules going, but only the one holding the lease runs them;
+// fires elsewhere are skipped as SkipStandby. The lease is renewed every
+// third of ttl, so if the primary goes away a standby takes over within
+// ttl and sends its tasks' notifiers EventFailover. Keep ttl below the
+// shortest interval for a takeover within one interval.
+//
+// A member that cannot renew in time stops running tasks before its lease
+// lapses. Standby stops the tasks and releases the lease once ctx ends,
+// returning ctx's error, or once StopAll or Drain is called, returning
+// nil.
+func (s *Scheduler) Standby(ctx context.Context, e Elector, id string, ttl time.Duration) error {
+	if ttl <= 0 {
+		return fmt.Errorf("%w: lease ttl %s", ErrInvalidValue, ttl)
+	}
+	sb := &standby{quit: make(chan struct{}), left: make(chan struct{})}
+	s.mu.Lock()
+	s.standby = sb
+	for _, t := range s.tasks {
+		t.standby.Store(sb)
+	}
+	logger := s.logger
+	s.mu.Unlock()
+	if logger == nil {
+		logger = discardLogger
+	}
+	renew := func(primary bool) bool {
+		start := time.Now()
+		held, err := e.Acquire(ctx, id, ttl)
+		switch {
+		case err != nil && ctx.Err() == nil:
+			logger.Warn("lease renewal failed", "id", id, "error", err)
+			return primary && sb.primary()
+		case !held:
+			sb.until.Store(0)
+			if primary {
+				logger.Warn("lease lost, standing by", "id", id)
+			}
+			return false
+		}
+		// The lease may have been granted at any point during the call, so
+		// it is counted from when the call started.
+		sb.until.Store(start.Add(ttl).UnixNano())
+		if !primary {
+			logger.Info("lease acquired, taking over", "id", id)
+			now := time.Now()
+			for _, t := range s.Tasks() {
+				if t.notifiers != nil {
+					t.notify(Event{Type: EventFailover, Time: now})
+				}
+			}
+		}
+		return true
+	}
+
+	defer close(sb.left)
+	primary := renew(false)
+	s.StartAll()
+	ticker := time.NewTicker(ttl / 3)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			primary = renew(primary)
+			continue
+		case <-ctx.Done():
+		case <-sb.quit:
+		}
+
+		sb.until.Store(0)
+		s.stopTasks()
+		if primary {
+			release, cancel := context.WithTimeout(context.WithoutCancel(ctx), ttl)
+			if err := e.Release(release, id); err != nil {
+				logger.Warn("lease release failed", "id", id, "error", err)
+			}
+			cancel()
+		}
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,186 @@
+package every
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// lease is an in-memory Elector.
+type lease struct {
+	mu       sync.Mutex
+	holder   string
+	expires  time.Time
+	released []string
+}
+
+func (l *lease) Acquire(_ context.Context, id string, ttl time.Duration) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	if l.holder != "" && l.holder != id && now.Before(l.expires) {
+		return false, nil
+	}
+	l.holder, l.expires = id, now.Add(ttl)
+	return true, nil
+}
+
+func (l *lease) Release(_ context.Context, id string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.holder == id {
+		l.holder = ""
+		l.released = append(l.released, id)
+	}
+	return nil
+}
+
+type eventChan chan Event
+
+func (c eventChan) Notify(_ context.Context, e Event) error {
+	c <- e
+	return nil
+}
+
+func TestStandbyFire(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		until time.Duration
+		runs  uint64
+		skips int64
+	}{
+		{"primary", time.Minute, 1, 0},
+		{"standby", 0, 0, 1},
+		{"lapsed", -time.Second, 0, 1},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			task, err := NewTask("1s", func() {})
+			if err != nil {
+				t.Fatal(err)
+			}
+			sb := &standby{}
+			if tc.until != 0 {
+				sb.until.Store(time.Now().Add(tc.until).UnixNano())
+			}
+			task.standby.Store(sb)
+			task.timer = timers.get()
+			task.begin()
+			defer timers.put(task.timer)
+
+			now := time.Now()
+			task.due = now
+			task.fire(now)
+			if task.runs != tc.runs || task.Stats().Skips != tc.skips {
+				t.Errorf("%d runs, %d skips; want %d, %d", task.runs, task.Stats().Skips, tc.runs, tc.skips)
+			}
+		})
+	}
+}
+
+func TestStandbyFailover(t *testing.T) {
+	const ttl = 60 * time.Millisecond
+	l := &lease{}
+	type member struct {
+		s      *Scheduler
+		task   *Task
+		events eventChan
+		cancel context.CancelFunc
+		done   chan error
+	}
+	start := func(id string) *member {
+		m := &member{s: NewScheduler(), events: make(eventChan, 4), done: make(chan error, 1)}
+		m.task, _ = NewTask("1h", func() {}, WithNotifier(m.events, EventFailover))
+		m.s.Add(m.task)
+		var ctx context.Context
+		ctx, m.cancel = context.WithCancel(context.Background())
+		go func() { m.done <- m.s.Standby(ctx, l, id, ttl) }()
+		return m
+	}
+	primary := func(m *member) bool {
+		sb := m.task.standby.Load()
+		return sb != nil && sb.primary()
+	}
+	failover := func(m *member) {
+		t.Helper()
+		select {
+		case e := <-m.events:
+			if e.Type != EventFailover {
+				t.Errorf("event %v, want EventFailover", e.Type)
+			}
+		case <-time.After(2 * ttl):
+			t.Fatal("no EventFailover")
+		}
+	}
+
+	a := start("a")
+	failover(a)
+	b := start("b")
+	defer b.cancel()
+	time.Sleep(ttl)
+	if !primary(a) || primary(b) {
+		t.Fatalf("primary: a %v, b %v; want only a", primary(a), primary(b))
+	}
+
+	a.cancel()
+	if err := <-a.done; !errors.Is(err, context.Canceled) {
+		t.Errorf("Standby() = %v, want context.Canceled", err)
+	}
+	if primary(a) || a.task.State() != StateStopped {
+		t.Errorf("a still primary or running after its context ended: %v", a.task.State())
+	}
+	l.mu.Lock()
+	released := l.released
+	l.mu.Unlock()
+	if len(released) != 1 || released[0] != "a" {
+		t.Errorf("released %v, want [a]", released)
+	}
+
+	failover(b)
+	if !primary(b) {
+		t.Error("b did not take over")
+	}
+	b.cancel()
+	<-b.done
+}
+
+func TestStandbyStopReleases(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		stop func(*Scheduler)
+	}{
+		{"StopAll", (*Scheduler).StopAll},
+		{"Drain", func(s *Scheduler) { s.Drain(context.Background()) }},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			l := &lease{}
+			s := NewScheduler()
+			task, _ := NewTask("1h", func() {})
+			s.Add(task)
+			done := make(chan error, 1)
+			go func() { done <- s.Standby(context.Background(), l, "a", time.Minute) }()
+			for sb := task.standby.Load(); sb == nil || !sb.primary(); sb = task.standby.Load() {
+				time.Sleep(time.Millisecond)
+			}
+
+			tc.stop(s)
+			select {
+			case err := <-done:
+				if err != nil {
+					t.Errorf("Standby() = %v, want nil", err)
+				}
+			case <-time.After(time.Second):
+				t.Fatal("Standby did not return")
+			}
+			l.mu.Lock()
+			released := l.released
+			l.mu.Unlock()
+			if len(released) != 1 || released[0] != "a" || task.State() != StateStopped {
+				t.Errorf("released %v, task %v; want [a], stopped", released, task.State())
+			}
+		})
+	}
+}
@@ -0,0 +1,195 @@
+package every
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// JobStore persists data tasks keep between runs and across restarts,
+// keyed by task name.
+type JobStore interface {
+	Load(task string) (map[string][]byte, error)
+	Save(task string, values map[string][]byte) error
+}
+
+// WithJobStore gives the task a StateBag backed by store. The bag is loaded
+// before the first run and saved after every run that changed it; a run
+// fails if either step does. For a named task, schedule changes made with
+// Reschedule, UpdateInterval or Rollback are saved to store too, history
+// included, and restored when the task starts again, unless it has since
+// been built with a different schedule.
+func WithJobStore(store JobStore) Option {
+	return func(t *Task) {
+		t.store = store
+	}
+}
+
+// StateBag holds small values a task carries from one run to the next,
+// such as the last ID it processed.
+type StateBag struct {
+	mu     sync.Mutex
+	values map[string][]byte
+	dirty  bool
+}
+
+// StateBagFrom returns the StateBag of the task the run ctx belongs to. It
+// returns nil if the task has no JobStore; a nil StateBag holds nothing and
+// discards writes.
+func StateBagFrom(ctx context.Context) *StateBag {
+	r, ok := runFrom(ctx)
+	if !ok {
+		return nil
+	}
+	return r.task.bag
+}
+
+// Get returns the value stored under key.
+func (b *StateBag) Get(key string) ([]byte, bool) {
+	if b == nil {
+		return nil, false
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	v, ok := b.values[key]
+	return v, ok
+}
+
+// Set stores value under key; it is persisted when the run ends.
+func (b *StateBag) Set(key string, value []byte) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.values[key] = value
+	b.dirty = true
+}
+
+// Delete removes key.
+func (b *StateBag) Delete(key string) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, ok := b.values[key]; ok {
+		delete(b.values, key)
+		b.dirty = true
+	}
+}
+
+// loadBag reads the task's StateBag from its JobStore if it has not been
+// loaded yet.
+func (t *Task) loadBag() error {
+	if t.store == nil || t.bag != nil {
+		return nil
+	}
+	values, err := t.store.Load(t.name)
+	if err != nil {
+		return fmt.Errorf("loading state: %w", err)
+	}
+	if values == nil {
+		values = make(map[string][]byte)
+	}
+	t.bag = &StateBag{values: values}
+	return nil
+}
+
+// saveBag writes the task's StateBag back if a run changed it.
+func (t *Task) saveBag() error {
+	if t.bag == nil {
+		return nil
+	}
+	t.bag.mu.Lock()
+	dirty, values := t.bag.dirty, maps.Clone(t.bag.values)
+	t.bag.dirty = false
+	t.bag.mu.Unlock()
+
+	if !dirty {
+		return nil
+	}
+	if err := t.store.Save(t.name, values); err != nil {
+		t.bag.mu.Lock()
+		t.bag.dirty = true
+		t.bag.mu.Unlock()
+		return fmt.Errorf("saving state: %w", err)
+	}
+	return nil
+}
+
+// FileStore is a JobStore keeping one JSON file per task in a directory,
+// named after the task. Tasks without a name cannot use it.
+type FileStore struct {
+	dir string
+}
+
+// NewFileStore returns a FileStore in dir, creating it if needed.
+func NewFileStore(dir string) (*FileStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &FileStore{dir: dir}, nil
+}
+
+func (s *FileStore) path(task string) (string, error) {
+	if task == "" {
+		return "", fmt.Errorf("%w: FileStore needs a task name", ErrInvalidValue)
+	}
+	return filepath.Join(s.dir, url.PathEscape(task)+".json"), nil
+}
+
+func (s *FileStore) Load(task string) (map[string][]byte, error) {
+	path, err := s.path(task)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var values map[string][]byte
+	if err := json.Unmarshal(data, &values); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// Save replaces the task's file atomically, so a crash leaves either the
+// old values or the new ones.
+func (s *FileStore) Save(task string, values map[string][]byte) error {
+	path, err := s.path(task)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(values)
+	if err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(s.dir, ".every-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
@@ -0,0 +1,34 @@
+package every
+
+import (
+	"errors"
+	"maps"
+	"testing"
+)
+
+func TestFileStore(t *testing.T) {
+	s, err := NewFileStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range []struct {
+		task string
+		err  error
+	}{
+		{"sync", nil},
+		{"reports/daily", nil},
+		{"", ErrInvalidValue},
+	} {
+		values := map[string][]byte{"cursor": []byte(tc.task)}
+		if err := s.Save(tc.task, values); !errors.Is(err, tc.err) {
+			t.Errorf("Save(%q) = %v, want %v", tc.task, err, tc.err)
+		}
+		got, err := s.Load(tc.task)
+		if !errors.Is(err, tc.err) {
+			t.Errorf("Load(%q) = %v, want %v", tc.task, err, tc.err)
+		}
+		if tc.err == nil && !maps.EqualFunc(got, values, func(a, b []byte) bool { return string(a) == string(b) }) {
+			t.Errorf("Load(%q) = %q, want %q", tc.task, got, values)
+		}
+	}
+}
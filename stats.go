@@ -0,0 +1,107 @@
+package every
+
+import (
+	"slices"
+	"sync/atomic"
+	"time"
+)
+
+// latencyWindow is how many recent run durations percentiles are computed
+// over. A window rather than the full history keeps percentiles reacting
+// to regressions in long-running processes.
+const latencyWindow = 256
+
+type Stats struct {
+	Runs          int64         `json:"runs"`
+	Skips         int64         `json:"skips"`
+	Errors        int64         `json:"errors"`
+	Gaps          int64         `json:"gaps"`
+	LastError     string        `json:"last_error,omitempty"`
+	TotalDuration time.Duration `json:"total_duration"`
+	LastDuration  time.Duration `json:"last_duration"`
+	P50           time.Duration `json:"p50"`
+	P95           time.Duration `json:"p95"`
+	P99           time.Duration `json:"p99"`
+}
+
+// counters holds a task's statistics as atomics so the run loop can update
+// them without contending with readers. A reader may see one run's update
+// half applied, which is fine for monitoring.
+type counters struct {
+	runs, skips, errors atomic.Int64
+	gaps                atomic.Int64
+	failing             atomic.Bool
+	total, last         atomic.Int64
+	lastError           atomic.Pointer[string]
+	latencies           [latencyWindow]atomic.Int64
+	n                   atomic.Int64
+}
+
+func (c *counters) ran(d time.Duration, err error) {
+	c.runs.Add(1)
+	c.total.Add(int64(d))
+	c.last.Store(int64(d))
+	c.latencies[c.n.Load()%latencyWindow].Store(int64(d))
+	c.n.Add(1)
+	c.failing.Store(err != nil)
+	if err != nil {
+		c.errors.Add(1)
+		msg := err.Error()
+		c.lastError.Store(&msg)
+	}
+}
+
+func (c *counters) load() Stats {
+	stats := Stats{
+		Runs:          c.runs.Load(),
+		Skips:         c.skips.Load(),
+		Errors:        c.errors.Load(),
+		Gaps:          c.gaps.Load(),
+		TotalDuration: time.Duration(c.total.Load()),
+		LastDuration:  time.Duration(c.last.Load()),
+	}
+	if msg := c.lastError.Load(); msg != nil {
+		stats.LastError = *msg
+	}
+
+	n := min(int(c.n.Load()), latencyWindow)
+	if n == 0 {
+		return stats
+	}
+	sorted := make([]time.Duration, n)
+	for i := range sorted {
+		sorted[i] = time.Duration(c.latencies[i].Load())
+	}
+	slices.Sort(sorted)
+	at := func(p int) time.Duration {
+		return sorted[(n-1)*p/100]
+	}
+	stats.P50, stats.P95, stats.P99 = at(50), at(95), at(99)
+	return stats
+}
+
+func (t *Task) Stats() Stats {
+	return t.stats.load()
+}
+
+// atomicTime stores a time.Time as Unix nanoseconds, with 0 for the zero
+// time. The monotonic reading and location are dropped.
+type atomicTime struct {
+	ns atomic.Int64
+}
+
+func (a *atomicTime) Store(t time.Time) {
+	if t.IsZero() {
+		a.ns.Store(0)
+		return
+	}
+	a.ns.Store(t.UnixNano())
+}
+
+func (a *atomicTime) Load() time.Time {
+	ns := a.ns.Load()
+	if ns == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, ns)
+}
@@ -0,0 +1,58 @@
+package every
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"sync/atomic"
+)
+
+type subscriber struct {
+	fn func(ctx context.Context)
+}
+
+// subscribers is copied on write so the fire path reads it without
+// locking.
+type subscribers struct {
+	mu   sync.Mutex
+	list atomic.Pointer[[]*subscriber]
+}
+
+// Subscribe registers fn to be called once on every fire of the task,
+// after the task func's last attempt and with the same run context, so
+// several components can share one schedule. Listeners run one after
+// another in the order they subscribed, whether the run failed or not. The
+// returned func removes fn.
+func (t *Task) Subscribe(fn func(ctx context.Context)) (unsubscribe func()) {
+	s := &subscriber{fn: fn}
+	t.subs.update(func(list []*subscriber) []*subscriber {
+		return append(list, s)
+	})
+	return func() {
+		t.subs.update(func(list []*subscriber) []*subscriber {
+			return slices.DeleteFunc(list, func(other *subscriber) bool { return other == s })
+		})
+	}
+}
+
+func (s *subscribers) update(fn func([]*subscriber) []*subscriber) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var list []*subscriber
+	if p := s.list.Load(); p != nil {
+		list = slices.Clone(*p)
+	}
+	list = fn(list)
+	s.list.Store(&list)
+}
+
+func (s *subscribers) publish(ctx context.Context) {
+	p := s.list.Load()
+	if p == nil {
+		return
+	}
+	for _, sub := range *p {
+		sub.fn(ctx)
+	}
+}
@@ -0,0 +1,58 @@
+package every
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSubscribeOncePerFire(t *testing.T) {
+	for _, mode := range []struct {
+		name string
+		opts []Option
+	}{{"sync", nil}, {"async", []Option{WithAsyncRuns()}}} {
+		t.Run(mode.name, func(t *testing.T) {
+			var calls atomic.Int32
+			heard := make(chan int32, 1)
+			task, err := NewErrorTask("1h", func(context.Context) error {
+				calls.Add(1)
+				return errors.New("down")
+			}, append(mode.opts, WithRetry(2, nil))...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			task.Subscribe(func(ctx context.Context) {
+				if info, _ := RunInfoFrom(ctx); info.Attempt != 3 {
+					t.Errorf("subscriber called after attempt %d, want 3", info.Attempt)
+				}
+				heard <- calls.Load()
+			})
+			task.timer = timers.get()
+			task.begin()
+			defer func() {
+				task.asyncRuns.Wait()
+				timers.put(task.timer)
+				task.cancel()
+			}()
+			now := time.Now()
+			task.due = now
+			task.fire(now)
+
+			select {
+			case n := <-heard:
+				if n != 3 {
+					t.Errorf("subscriber called after %d attempts, want 3", n)
+				}
+			case <-time.After(time.Second):
+				t.Fatal("subscriber not called")
+			}
+			select {
+			case <-heard:
+				t.Error("subscriber called more than once for one fire")
+			case <-time.After(10 * time.Millisecond):
+			}
+		})
+	}
+}
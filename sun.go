@@ -0,0 +1,115 @@
+package every
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+var errNoCoordinates = errors.New("sunrise and sunset schedules require WithCoordinates")
+
+type coordinates struct {
+	lat, lon float64
+}
+
+// WithCoordinates sets the latitude and longitude, in degrees with north
+// and east positive, that "sunrise" and "sunset" schedules are computed for.
+func WithCoordinates(lat, lon float64) Option {
+	return func(t *Task) {
+		t.parser.coords = &coordinates{lat: lat, lon: lon}
+	}
+}
+
+// sunSchedule fires at sunrise or sunset plus an offset. Days on which the
+// sun does not rise or set, near the poles, are skipped.
+type sunSchedule struct {
+	spec   string
+	coords coordinates
+	sunset bool
+	offset time.Duration
+}
+
+func parseSun(spec string, coords *coordinates) (schedule, bool, error) {
+	s := sunSchedule{spec: spec}
+	rest, ok := strings.CutPrefix(spec, "sunrise")
+	if !ok {
+		if rest, ok = strings.CutPrefix(spec, "sunset"); !ok {
+			return nil, false, nil
+		}
+		s.sunset = true
+	}
+	if coords == nil {
+		return nil, true, errNoCoordinates
+	}
+	s.coords = *coords
+
+	if rest != "" {
+		sign := rest[0]
+		offset, err := parseDuration(rest[1:])
+		if err != nil || (sign != '+' && sign != '-') {
+			return nil, true, fmt.Errorf("%w: offset %s", ErrInvalidValue, spec)
+		}
+		if sign == '-' {
+			offset = -offset
+		}
+		s.offset = offset
+	}
+	return s, true, nil
+}
+
+func (s sunSchedule) String() string {
+	return s.spec
+}
+
+func (s sunSchedule) next(t time.Time) time.Time {
+	y, m, d := t.Date()
+	// Start a day early so a large negative offset on tomorrow's event is
+	// not missed, and look far enough ahead to get through a polar night.
+	for i := -1; i <= 366; i++ {
+		day := time.Date(y, m, d+i, 12, 0, 0, 0, time.UTC)
+		rise, set, ok := sunEvents(day, s.coords)
+		if !ok {
+			continue
+		}
+		at := rise
+		if s.sunset {
+			at = set
+		}
+		if at = at.Add(s.offset).In(t.Location()); at.After(t) {
+			return at
+		}
+	}
+	return time.Time{}
+}
+
+// sunEvents computes sunrise and sunset on the date of day using the
+// sunrise equation, which is accurate to about a minute away from the
+// poles. ok is false when the sun stays above or below the horizon.
+func sunEvents(day time.Time, c coordinates) (rise, set time.Time, ok bool) {
+	const rad = math.Pi / 180
+
+	jd := float64(day.Unix())/86400 + 2440587.5
+	n := math.Round(jd - 2451545.0 + 0.0008)
+	meanNoon := n - c.lon/360
+
+	anomaly := math.Mod(357.5291+0.98560028*meanNoon, 360)
+	center := 1.9148*math.Sin(anomaly*rad) + 0.02*math.Sin(2*anomaly*rad) + 0.0003*math.Sin(3*anomaly*rad)
+	ecliptic := math.Mod(anomaly+center+180+102.9372, 360)
+	transit := 2451545.0 + meanNoon + 0.0053*math.Sin(anomaly*rad) - 0.0069*math.Sin(2*ecliptic*rad)
+
+	sinDecl := math.Sin(ecliptic*rad) * math.Sin(23.4397*rad)
+	cosDecl := math.Cos(math.Asin(sinDecl))
+	cosHour := (math.Sin(-0.833*rad) - math.Sin(c.lat*rad)*sinDecl) / (math.Cos(c.lat*rad) * cosDecl)
+	if cosHour < -1 || cosHour > 1 {
+		return time.Time{}, time.Time{}, false
+	}
+	hour := math.Acos(cosHour) / rad / 360
+
+	return julianTime(transit - hour), julianTime(transit + hour), true
+}
+
+func julianTime(jd float64) time.Time {
+	return time.Unix(0, int64((jd-2440587.5)*86400*float64(time.Second))).UTC().Round(time.Second)
+}
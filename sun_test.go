@@ -0,0 +1,59 @@
+package every
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSunSchedule(t *testing.T) {
+	at := func(s string) time.Time {
+		d, _ := time.Parse("2006-01-02 15:04", s)
+		return d
+	}
+	for _, tc := range []struct {
+		spec     string
+		lat, lon float64
+		from     string
+		want     string
+	}{
+		{"sunrise", 51.5074, -0.1278, "2024-06-21 00:00", "2024-06-21 03:43"},
+		{"sunset", 51.5074, -0.1278, "2024-06-21 00:00", "2024-06-21 20:21"},
+		{"sunset-30m", 51.5074, -0.1278, "2024-06-21 00:00", "2024-06-21 19:51"},
+		{"sunrise+1h", 0, 0, "2024-03-20 00:00", "2024-03-20 07:04"},
+		// Past today's sunrise, the next one is tomorrow's.
+		{"sunrise", 0, 0, "2024-03-20 12:00", "2024-03-21 06:04"},
+	} {
+		task, err := NewTask(tc.spec, func() {}, WithCoordinates(tc.lat, tc.lon))
+		if err != nil {
+			t.Fatalf("%s: %v", tc.spec, err)
+		}
+		got := task.schedule.next(at(tc.from))
+		if d := got.Sub(at(tc.want)); d.Abs() > 2*time.Minute {
+			t.Errorf("%s at (%g, %g) after %s = %s, want about %s", tc.spec, tc.lat, tc.lon, tc.from, got, tc.want)
+		}
+	}
+}
+
+func TestSunSchedulePolarNight(t *testing.T) {
+	task, err := NewTask("sunrise", func() {}, WithCoordinates(69.65, 18.96))
+	if err != nil {
+		t.Fatal(err)
+	}
+	from, _ := time.Parse("2006-01-02", "2024-12-15")
+	got := task.schedule.next(from)
+	if got.Month() != time.January || got.Day() < 10 || got.Day() > 20 {
+		t.Errorf("first sunrise in Tromsø after %s = %s, want mid January", from, got)
+	}
+}
+
+func TestSunScheduleInvalid(t *testing.T) {
+	if _, err := NewTask("sunset", func() {}); !errors.Is(err, errNoCoordinates) {
+		t.Errorf("sunset without coordinates: %v", err)
+	}
+	for _, spec := range []string{"sunrise*5m", "sunset+", "sunrise+5x"} {
+		if _, err := NewTask(spec, func() {}, WithCoordinates(0, 0)); err == nil {
+			t.Errorf("%q parsed", spec)
+		}
+	}
+}
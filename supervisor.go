@@ -0,0 +1,69 @@
+package every
+
+import (
+	"fmt"
+	"runtime/debug"
+	"time"
+)
+
+// Supervisor is a Scheduler that restarts the run loop of any task that
+// panics, one for one: only the task that panicked is affected. A task
+// that panics more than maxRestarts times within period is given up on
+// and left stopped, as Erlang supervisors do.
+type Supervisor struct {
+	*Scheduler
+	maxRestarts int
+	period      time.Duration
+}
+
+func NewSupervisor(maxRestarts int, period time.Duration) *Supervisor {
+	sv := &Supervisor{Scheduler: NewScheduler(), maxRestarts: maxRestarts, period: period}
+	sv.Scheduler.sv = sv
+	return sv
+}
+
+// crashed handles a panic recovered from the task's run loop, counting it
+// as a failed run if it came from the task func. It reports whether the
+// loop should be restarted.
+func (t *Task) crashed(r any) bool {
+	err := fmt.Errorf("panic: %v", r)
+	if t.State() == StateRunning {
+		t.stats.ran(time.Since(t.lastRun.Load()), err)
+	}
+	t.log.Error("task panicked", "error", err, "stack", string(debug.Stack()))
+	if t.stopping.Load() {
+		t.end()
+		return false
+	}
+
+	sv, now := t.supervisor, time.Now()
+	for len(t.restarts) > 0 && now.Sub(t.restarts[0]) > sv.period {
+		t.restarts = t.restarts[1:]
+	}
+	if len(t.restarts) >= sv.maxRestarts {
+		t.log.Error("task restarted too often, giving up", "restarts", len(t.restarts), "period", sv.period)
+		t.stopping.Store(true)
+		t.setState(StateStopped, time.Time{})
+		t.stopped()
+		t.closeOutbox()
+		return false
+	}
+	t.restarts = append(t.restarts, now)
+	return true
+}
+
+// recoverAfterFunc is the AfterFunc mode counterpart of the recovery in
+// loop: the callbacks hold loopMu, so a restart re-arms the task in place.
+func (t *Task) recoverAfterFunc() {
+	r := recover()
+	if r == nil {
+		return
+	}
+	if t.crashed(r) {
+		t.begin()
+		return
+	}
+	t.timer.Stop()
+	t.kick.Load().Stop()
+	t.exited()
+}
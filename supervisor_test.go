@@ -0,0 +1,56 @@
+package every
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// within fails the test if f does not return within a second.
+func within(t *testing.T, f func() error) error {
+	t.Helper()
+	done := make(chan error, 1)
+	go func() { done <- f() }()
+	select {
+	case err := <-done:
+		return err
+	case <-time.After(time.Second):
+		t.Fatal("call blocked")
+		return nil
+	}
+}
+
+func TestSupervisorGivenUpTaskRejectsUpdates(t *testing.T) {
+	for _, mode := range []struct {
+		name string
+		opts []Option
+	}{{"timer", nil}, {"afterfunc", []Option{WithAfterFunc()}}} {
+		t.Run(mode.name, func(t *testing.T) {
+			sv := NewSupervisor(0, time.Minute)
+			task, _ := NewTask("1s", func() { panic("boom") }, mode.opts...)
+			sv.Add(task)
+			if err := task.TryStart(); err != nil {
+				t.Fatal(err)
+			}
+			defer task.Stop()
+			select {
+			case <-task.Done():
+			case <-time.After(3 * time.Second):
+				t.Fatal("supervisor did not give up")
+			}
+
+			for name, f := range map[string]func() error{
+				"UpdateInterval": func() error { return task.UpdateInterval("1h") },
+				"Reschedule":     func() error { return task.Reschedule("2h") },
+				"RunNow":         task.RunNow,
+			} {
+				if err := within(t, f); !errors.Is(err, ErrTaskStopped) {
+					t.Errorf("%s() = %v, want ErrTaskStopped", name, err)
+				}
+			}
+			if got := task.State(); got != StateStopped {
+				t.Errorf("State() = %v, want stopped", got)
+			}
+		})
+	}
+}
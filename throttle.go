@@ -0,0 +1,56 @@
+package every
+
+import (
+	"context"
+	"time"
+)
+
+// Throttler runs its func on Trigger at most once per interval. A Trigger
+// within an interval of the last run schedules a single trailing run for
+// when the interval is up.
+type Throttler struct {
+	*Task
+}
+
+type throttle struct {
+	task *Task
+	last time.Time
+}
+
+func NewThrottler(interval string, fn func(), opts ...Option) (*Throttler, error) {
+	return NewContextThrottler(interval, func(context.Context) { fn() }, opts...)
+}
+
+func NewContextThrottler(interval string, fn func(ctx context.Context), opts ...Option) (*Throttler, error) {
+	duration, err := parseDuration(interval)
+	if err != nil {
+		return nil, err
+	}
+
+	t := newTask(intervalSchedule(duration), func(ctx context.Context) error {
+		fn(ctx)
+		return nil
+	})
+	t.apply(opts)
+	t.events = &throttle{task: t}
+	return &Throttler{Task: t}, nil
+}
+
+func (th *Throttler) Trigger() {
+	th.trigger()
+}
+
+func (th *throttle) trigger(now, next time.Time) time.Time {
+	if !next.IsZero() {
+		return next
+	}
+	if at := th.last.Add(th.task.interval()); !th.last.IsZero() && now.Before(at) {
+		return at
+	}
+	return now
+}
+
+func (th *throttle) ran(start, _ time.Time) time.Time {
+	th.last = start
+	return time.Time{}
+}
@@ -0,0 +1,40 @@
+package every
+
+import (
+	"testing"
+	"time"
+)
+
+func TestThrottle(t *testing.T) {
+	// Each step is a trigger at the given second, which should leave the
+	// fire pending at want, or a run at that second when want is -1.
+	type step struct{ at, want int }
+	for _, tc := range []struct {
+		name  string
+		steps []step
+	}{
+		{"first trigger runs at once", []step{{0, 0}}},
+		{"trigger within the interval trails", []step{{0, 0}, {0, -1}, {3, 10}}},
+		{"one trailing run", []step{{0, 0}, {0, -1}, {3, 10}, {5, 10}, {9, 10}}},
+		{"after the interval runs at once", []step{{0, 0}, {0, -1}, {12, 12}}},
+		{"trailing run restarts the interval", []step{{0, 0}, {0, -1}, {3, 10}, {10, -1}, {15, 20}}},
+	} {
+		th, err := NewThrottler("10s", func() {})
+		if err != nil {
+			t.Fatal(err)
+		}
+		start := time.Now()
+		sec := func(s int) time.Time { return start.Add(time.Duration(s) * time.Second) }
+		var next time.Time
+		for _, st := range tc.steps {
+			if st.want < 0 {
+				next = th.events.ran(sec(st.at), sec(st.at))
+				continue
+			}
+			next = th.events.trigger(sec(st.at), next)
+			if !next.Equal(sec(st.want)) {
+				t.Errorf("%s: trigger at %ds fires at %s, want %ds", tc.name, st.at, next.Sub(start), st.want)
+			}
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package every
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// WithTimeout cancels each run's context once it has run for d. A run
+// still going at that point counts as failed with an error wrapping
+// ErrTimeout, whatever it returns; the task func must watch its context
+// for the run to actually end early.
+func WithTimeout(d time.Duration) Option {
+	return func(t *Task) {
+		t.timeout = d
+	}
+}
+
+func (t *Task) runTimeout(parent context.Context) error {
+	ctx, cancel := context.WithTimeoutCause(parent, t.timeout, ErrTimeout)
+	defer cancel()
+
+	err := t.taskFunc(ctx)
+	if context.Cause(ctx) == ErrTimeout {
+		return fmt.Errorf("%w after %s", ErrTimeout, t.timeout)
+	}
+	return err
+}
@@ -0,0 +1,69 @@
+package every
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// maxIdleTimers caps how many stopped timers are kept for reuse.
+const maxIdleTimers = 1024
+
+// TimerPoolStats reports how the timers behind task run loops are reused.
+// Programs that create and stop many short-lived tasks should see Reused
+// approach Gets.
+type TimerPoolStats struct {
+	Gets   uint64
+	Reused uint64
+	Puts   uint64
+	Idle   int
+}
+
+type timerPool struct {
+	mu    sync.Mutex
+	idle  []*time.Timer
+	stats TimerPoolStats
+}
+
+var timers timerPool
+
+// get returns a stopped timer.
+func (p *timerPool) get() *time.Timer {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.stats.Gets++
+	if n := len(p.idle); n > 0 {
+		t := p.idle[n-1]
+		p.idle = p.idle[:n-1]
+		p.stats.Reused++
+		return t
+	}
+
+	t := time.NewTimer(math.MaxInt64)
+	t.Stop()
+	return t
+}
+
+// put stops t and keeps it for reuse. Since Go 1.23 a stopped timer never
+// delivers a stale value, so it is safe to hand to another task.
+func (p *timerPool) put(t *time.Timer) {
+	t.Stop()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.stats.Puts++
+	if len(p.idle) < maxIdleTimers {
+		p.idle = append(p.idle, t)
+	}
+}
+
+func ReadTimerPoolStats() TimerPoolStats {
+	timers.mu.Lock()
+	defer timers.mu.Unlock()
+
+	stats := timers.stats
+	stats.Idle = len(timers.idle)
+	return stats
+}
@@ -0,0 +1,119 @@
+package every
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+)
+
+// DecisionKind says what a traced scheduling decision was.
+type DecisionKind int
+
+const (
+	// DecisionSchedule means the next fire was chosen.
+	DecisionSchedule DecisionKind = iota
+	// DecisionFire means a fire went ahead and the task ran.
+	DecisionFire
+	// DecisionSkip means a fire was skipped.
+	DecisionSkip
+)
+
+func (k DecisionKind) String() string {
+	switch k {
+	case DecisionSchedule:
+		return "schedule"
+	case DecisionFire:
+		return "fire"
+	case DecisionSkip:
+		return "skip"
+	}
+	return "unknown"
+}
+
+func (k DecisionKind) MarshalText() ([]byte, error) {
+	return []byte(k.String()), nil
+}
+
+func (k *DecisionKind) UnmarshalText(text []byte) error {
+	for v := DecisionSchedule; v <= DecisionSkip; v++ {
+		if v.String() == string(text) {
+			*k = v
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: decision kind %s", ErrInvalidValue, text)
+}
+
+// Decision is one entry of a task's decision trace. Which fields are set
+// depends on Kind: Next and Jitter for schedule decisions, Scheduled,
+// Late and Waited for fires, Reason and Count for skips. Queued is the
+// number of fires queued behind a run at the time.
+type Decision struct {
+	Time      time.Time     `json:"time"`
+	Kind      DecisionKind  `json:"kind"`
+	Next      time.Time     `json:"next"`
+	Jitter    time.Duration `json:"jitter,omitempty"`
+	Scheduled time.Time     `json:"scheduled"`
+	Late      time.Duration `json:"late,omitempty"`
+	Waited    time.Duration `json:"waited,omitempty"`
+	Reason    string        `json:"reason,omitempty"`
+	Count     int           `json:"count,omitempty"`
+	Queued    int           `json:"queued,omitempty"`
+}
+
+// trace keeps the last decisions of a task in a ring.
+type trace struct {
+	mu   sync.Mutex
+	ring []Decision
+	n    int
+}
+
+// WithTrace records the task's last n scheduling decisions, such as why a
+// fire was skipped or how long it waited for a pool slot, for Trace and
+// snapshots.
+func WithTrace(n int) Option {
+	return func(t *Task) {
+		t.traceSize = n
+	}
+}
+
+// Trace returns the task's traced decisions, oldest first. It is empty
+// unless the task was created WithTrace.
+func (t *Task) Trace() []Decision {
+	t.trace.mu.Lock()
+	defer t.trace.mu.Unlock()
+
+	ring := t.trace.ring
+	i := t.trace.n % max(len(ring), 1)
+	if t.trace.n <= len(ring) {
+		i = 0
+	}
+	return append(slices.Clone(ring[i:]), ring[:i]...)
+}
+
+// decide adds d to the trace if the task keeps one.
+func (t *Task) decide(d Decision) {
+	if t.traceSize <= 0 {
+		return
+	}
+	d.Time = time.Now()
+	d.Queued = len(t.queue)
+	t.trace.mu.Lock()
+	defer t.trace.mu.Unlock()
+
+	if len(t.trace.ring) < t.traceSize {
+		t.trace.ring = append(t.trace.ring, d)
+	} else {
+		t.trace.ring[t.trace.n%t.traceSize] = d
+	}
+	t.trace.n++
+}
+
+// traceNext traces the choice of t.due as the next fire.
+func (t *Task) traceNext() {
+	if t.traceSize > 0 {
+		t.decide(Decision{Kind: DecisionSchedule, Next: t.due, Jitter: t.jitterShift})
+		t.jitterShift = 0
+	}
+}
@@ -0,0 +1,91 @@
+package every
+
+import "time"
+
+// WithTrigger runs the task as soon as a value arrives on ch, in addition
+// to its schedule; afterwards the schedule carries on where it was. For a
+// Debouncer or Throttler a receive counts as a Trigger call. Closing ch
+// stops the triggers.
+func WithTrigger(ch <-chan struct{}) Option {
+	return func(t *Task) {
+		t.extTrigger, t.extReset = ch, false
+	}
+}
+
+// WithTriggerReset is like WithTrigger, but the schedule restarts from each
+// triggered run, so an interval task next fires a full interval after it.
+func WithTriggerReset(ch <-chan struct{}) Option {
+	return func(t *Task) {
+		t.extTrigger, t.extReset = ch, true
+	}
+}
+
+// external handles a receive from the WithTrigger channel.
+func (t *Task) external() {
+	if t.dedupKey != nil && t.duplicate(time.Now()) {
+		return
+	}
+	t.runNow(t.extReset)
+}
+
+// RunNow fires the task straight away, as a WithTrigger receive would,
+// and returns once the run loop has taken the request; afterwards the
+// schedule carries on where it was. It returns ErrNotStarted for a task
+// not yet started and ErrTaskStopped for a stopped one.
+func (t *Task) RunNow() error {
+	if t.afterFunc {
+		t.loopMu.Lock()
+		defer t.loopMu.Unlock()
+
+		if t.stopping.Load() {
+			return ErrTaskStopped
+		}
+		if t.timer == nil {
+			return ErrNotStarted
+		}
+		t.runNow(false)
+		return nil
+	}
+	if ok, err := t.unstarted(func() {}); ok {
+		if err == nil {
+			err = ErrNotStarted
+		}
+		return err
+	}
+	select {
+	case t.runChan <- struct{}{}:
+		return nil
+	case <-t.stopChan:
+		return ErrTaskStopped
+	case <-t.done:
+		return ErrTaskStopped
+	}
+}
+
+// runNow brings the next fire forward to now. Unless reset, the scheduled
+// fire it jumped ahead of comes next.
+func (t *Task) runNow(reset bool) {
+	if t.events != nil {
+		t.triggered()
+		return
+	}
+
+	if !reset && t.resume.IsZero() {
+		t.resume = t.due
+	}
+	t.due = time.Now()
+	t.arm()
+	t.setState(StateWaiting, t.due)
+}
+
+// following returns the fire time after a fire handled at now: the
+// scheduled fire a trigger jumped ahead of, if any, or the schedule's next.
+func (t *Task) following(now time.Time) (time.Time, int) {
+	if next := t.resume; !next.IsZero() {
+		t.resume = time.Time{}
+		return next, 0
+	}
+
+	next, dropped := t.nextCounted(now)
+	return t.jittered(now, next), dropped
+}
@@ -0,0 +1,85 @@
+package every
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRunNow(t *testing.T) {
+	for _, mode := range []struct {
+		name string
+		opts []Option
+	}{{"timer", nil}, {"afterfunc", []Option{WithAfterFunc()}}} {
+		t.Run(mode.name, func(t *testing.T) {
+			ran := make(chan struct{}, 1)
+			task, err := NewTask("1h", func() { ran <- struct{}{} }, mode.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := within(t, task.RunNow); !errors.Is(err, ErrNotStarted) {
+				t.Errorf("RunNow() = %v before Start, want ErrNotStarted", err)
+			}
+
+			if err := task.TryStart(); err != nil {
+				t.Fatal(err)
+			}
+			next := time.Now().Add(time.Hour)
+			for task.State() != StateWaiting {
+				time.Sleep(time.Millisecond)
+			}
+			if err := within(t, task.RunNow); err != nil {
+				t.Fatal(err)
+			}
+			select {
+			case <-ran:
+			case <-time.After(time.Second):
+				t.Fatal("RunNow did not run the task")
+			}
+			for task.State() != StateWaiting {
+				time.Sleep(time.Millisecond)
+			}
+			if got := task.Snapshot().NextRun; got.Sub(next).Abs() > time.Second {
+				t.Errorf("next run %v after RunNow, want the scheduled %v", got, next)
+			}
+
+			task.Stop()
+			if err := within(t, task.RunNow); !errors.Is(err, ErrTaskStopped) {
+				t.Errorf("RunNow() = %v after Stop, want ErrTaskStopped", err)
+			}
+		})
+	}
+}
+
+func TestPause(t *testing.T) {
+	var skipped []SkipReason
+	task, err := NewTask("1s", func() {}, OnSkip(func(r SkipReason) { skipped = append(skipped, r) }))
+	if err != nil {
+		t.Fatal(err)
+	}
+	task.timer = timers.get()
+	task.begin()
+	defer timers.put(task.timer)
+
+	task.Pause()
+	if !task.Paused() || !task.Snapshot().Paused {
+		t.Error("task not reported paused after Pause")
+	}
+	now := time.Now()
+	task.due = now
+	task.fire(now)
+	if task.runs != 0 || len(skipped) != 1 || skipped[0] != SkipPaused {
+		t.Errorf("paused fire: %d runs, skips %v", task.runs, skipped)
+	}
+	if !task.due.After(now) {
+		t.Errorf("paused fire left next at %v, want after %v", task.due, now)
+	}
+
+	task.Resume()
+	now = time.Now()
+	task.due = now
+	task.fire(now)
+	if task.runs != 1 {
+		t.Errorf("resumed fire: %d runs, want 1", task.runs)
+	}
+}
@@ -0,0 +1,43 @@
+package every
+
+import (
+	"strings"
+	"time"
+)
+
+// unionSchedule fires whenever any of its schedules does, once for fire
+// times they share. An interval in a union is measured from the union's
+// previous fire, whichever schedule that came from.
+type unionSchedule []schedule
+
+func (u unionSchedule) next(t time.Time) time.Time {
+	var next time.Time
+	for _, s := range u {
+		if n := s.next(t); !n.IsZero() && (next.IsZero() || n.Before(next)) {
+			next = n
+		}
+	}
+	return next
+}
+
+func (u unionSchedule) String() string {
+	specs := make([]string, len(u))
+	for i, s := range u {
+		specs[i] = s.String()
+	}
+	return strings.Join(specs, " | ")
+}
+
+// parseUnion parses schedules separated by "|", such as
+// "daily@03:00 | 0 */6 * * 0,6".
+func (p parser) parseUnion(spec string) (schedule, error) {
+	var u unionSchedule
+	for _, part := range strings.Split(spec, "|") {
+		s, err := p.parse(strings.TrimSpace(part))
+		if err != nil {
+			return nil, err
+		}
+		u = append(u, s)
+	}
+	return u, nil
+}
@@ -0,0 +1,56 @@
+package every
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestUpdateBeforeStart(t *testing.T) {
+	for _, mode := range []struct {
+		name string
+		opts []Option
+	}{{"timer", nil}, {"afterfunc", []Option{WithAfterFunc()}}} {
+		t.Run(mode.name, func(t *testing.T) {
+			task, err := NewTask("1h", func() {}, mode.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := within(t, func() error { return task.UpdateInterval("2h") }); err != nil {
+				t.Fatal(err)
+			}
+			if err := within(t, func() error { return task.Reschedule("daily@06:00") }); err != nil {
+				t.Fatal(err)
+			}
+			if got := task.Schedule(); got != "daily@06:00" {
+				t.Errorf("Schedule() = %q before Start", got)
+			}
+
+			if err := task.TryStart(); err != nil {
+				t.Fatal(err)
+			}
+			defer task.Stop()
+			if err := within(t, task.Rollback); err != nil {
+				t.Fatal(err)
+			}
+			if got := task.Schedule(); got != "2h" {
+				t.Errorf("Schedule() = %q after Rollback", got)
+			}
+		})
+	}
+}
+
+func TestUpdateAfterStop(t *testing.T) {
+	for _, mode := range []struct {
+		name string
+		opts []Option
+	}{{"timer", nil}, {"afterfunc", []Option{WithAfterFunc()}}} {
+		t.Run(mode.name, func(t *testing.T) {
+			task, _ := NewTask("1h", func() {}, mode.opts...)
+			task.Stop()
+			err := within(t, func() error { return task.UpdateInterval("2h") })
+			if !errors.Is(err, ErrTaskStopped) {
+				t.Errorf("UpdateInterval() = %v, want ErrTaskStopped", err)
+			}
+		})
+	}
+}
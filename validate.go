@@ -0,0 +1,64 @@
+package every
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Validate checks every task for mistakes that would otherwise only show
+// up once it fires, such as a nil func or options that contradict each
+// other or the schedule, and returns them all joined, or nil. Call it
+// before StartAll to fail fast at boot.
+func (s *Scheduler) Validate() error {
+	var errs []error
+	names := make(map[string]TaskID)
+	for _, t := range s.Tasks() {
+		label := t.name
+		if label == "" {
+			label = fmt.Sprintf("#%d", t.id)
+		}
+		if id, ok := names[t.name]; ok && t.name != "" {
+			errs = append(errs, fmt.Errorf("task %s: name also used by task #%d", label, id))
+		}
+		names[t.name] = t.id
+		for _, err := range t.validate() {
+			errs = append(errs, fmt.Errorf("task %s: %w", label, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (t *Task) validate() []error {
+	var errs []error
+	check := func(bad bool, format string, args ...any) {
+		if bad {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+
+	_, interval := t.currentSchedule().(intervalSchedule)
+	check(t.taskFunc == nil && t.missingFunc == MissingFuncFail, "nil func")
+	check(t.timeout < 0, "negative timeout %s", t.timeout)
+	check(t.retries < 0, "negative retry count %d", t.retries)
+	check(t.cooldown < 0, "negative cooldown %s", t.cooldown)
+	check(t.panicLimit > 0 && t.panicWindow <= 0, "panic limit without a window")
+	check(t.breakerLimit < 0, "negative circuit breaker limit %d", t.breakerLimit)
+	check(t.breakerLimit > 0 && t.breakerOpen <= 0, "circuit breaker without a cooldown")
+	check(t.dedupKey != nil && t.extTrigger == nil, "dedup key without a trigger channel")
+	check(t.dedupKey != nil && t.dedupWindow <= 0, "dedup key without a window")
+	check(t.maxWait > 0 && t.events == nil, "max wait on a task that is not a Debouncer")
+	check(t.queueDepth > 0 && interval, "queue on an interval schedule, which never overlaps")
+	check(t.queueDepth < 0, "negative queue depth %d", t.queueDepth)
+	check(t.jitter != nil && !interval, "jitter on a calendar schedule, which ignores it")
+	check(t.rebase != RebaseFromNow && !interval, "rebase policy on a calendar schedule, which ignores it")
+	check(t.phases > 0 && !interval, "phase on a calendar schedule, which ignores it")
+	check(t.phases < 0 || t.phase < 0 || t.phases > 0 && t.phase >= t.phases, "phase %d/%d out of range", t.phase, t.phases)
+	check(t.splay < 0, "negative splay %s", t.splay)
+	check(t.preempting && t.pool == nil, "preemption without a worker pool")
+	check(t.poolWeight < 0, "negative weight %g", t.poolWeight)
+	check(t.final && t.events != nil, "final run on a triggered task")
+	check(t.async && (t.panicLimit > 0 || t.breakerLimit > 0 || t.store != nil || t.queueDepth > 0), "async runs with a panic limit, circuit breaker, job store or queue")
+	check(t.store != nil && t.name == "", "job store on a task without a name, which would share its state")
+	check(t.delivery != DeliveryBestEffort && (t.store == nil || t.name == ""), "delivery guarantee without a JobStore and name")
+	return errs
+}
@@ -0,0 +1,101 @@
+package every
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// ValueTask is a task whose func produces a value, such as a periodically
+// refreshed cache. It keeps the result of the latest successful run.
+type ValueTask[T any] struct {
+	*Task
+	mu    sync.Mutex
+	value T
+	at    time.Time
+	err   error
+	feeds []*Feed[T]
+}
+
+func NewValueTask[T any](interval string, fn func(ctx context.Context) (T, error), opts ...Option) (*ValueTask[T], error) {
+	v := &ValueTask[T]{}
+	t, err := NewErrorTask(interval, func(ctx context.Context) error {
+		value, err := fn(ctx)
+		for _, f := range v.store(value, err) {
+			f.send(ctx, value)
+		}
+		return err
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	v.Task = t
+	return v, nil
+}
+
+// Last returns the value of the latest successful run and when that run
+// finished, or the zero value and zero time if no run has succeeded yet.
+// The error is that of the latest run, so a non-nil error alongside a
+// value means the value is stale.
+func (v *ValueTask[T]) Last() (T, time.Time, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	return v.value, v.at, v.err
+}
+
+// Results returns a Feed receiving the value of every successful run from
+// now on, buffering up to size values. With OverflowBlock a full Feed holds
+// up the task until there is room or the task is stopped.
+func (v *ValueTask[T]) Results(size int, policy OverflowPolicy) *Feed[T] {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	f := newFeed[T](size, policy)
+	v.feeds = append(v.feeds, f)
+	return f
+}
+
+// store records the outcome of a run, returning the feeds to send a
+// successful value to.
+func (v *ValueTask[T]) store(value T, err error) []*Feed[T] {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	v.err = err
+	if err != nil {
+		return nil
+	}
+	v.value, v.at = value, time.Now()
+	return v.feeds
+}
+
+// Refresher keeps a value fetched periodically, such as a config document
+// or an access token. Get never blocks on a fetch: after a failed fetch it
+// keeps returning the last good value, until a later fetch succeeds.
+type Refresher[T any] struct {
+	*ValueTask[T]
+}
+
+func NewRefresher[T any](interval string, fetch func(ctx context.Context) (T, error), opts ...Option) (*Refresher[T], error) {
+	v, err := NewValueTask(interval, fetch, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &Refresher[T]{v}, nil
+}
+
+// Get returns the latest successfully fetched value, or the zero value
+// before the first fetch succeeds; WaitForFirstRun waits for that.
+func (r *Refresher[T]) Get() T {
+	v, _, _ := r.Last()
+	return v
+}
+
+// Stale reports whether the latest fetch failed, so Get is returning an
+// older value, or nothing has been fetched yet.
+func (r *Refresher[T]) Stale() bool {
+	_, at, err := r.Last()
+	return err != nil || at.IsZero()
+}
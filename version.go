@@ -0,0 +1,55 @@
+package every
+
+// maxHistory is how many earlier schedules a task keeps for Rollback.
+const maxHistory = 16
+
+// Version counts the task's schedule changes, starting at 1; every
+// successful Reschedule, UpdateInterval or Rollback adds one.
+func (t *Task) Version() uint64 {
+	t.versionMu.Lock()
+	defer t.versionMu.Unlock()
+
+	return t.version + 1
+}
+
+// Rollback restores the schedule the task had before its most recent
+// change, as Reschedule would. Successive calls step further back, up to
+// 16 changes. It returns ErrNoHistory if there is nothing to
+// restore.
+func (t *Task) Rollback() error {
+	t.versionMu.Lock()
+	defer t.versionMu.Unlock()
+
+	if len(t.history) == 0 {
+		return ErrNoHistory
+	}
+	prev := t.history[len(t.history)-1]
+	if err := t.update(prev); err != nil {
+		return err
+	}
+	t.history = t.history[:len(t.history)-1]
+	t.version++
+	t.saveSchedule()
+	return nil
+}
+
+// change replaces the task's schedule with s, remembering the old one.
+func (t *Task) change(s schedule) error {
+	t.versionMu.Lock()
+	defer t.versionMu.Unlock()
+
+	if t.persists() {
+		t.baseline()
+	}
+	prev := t.currentSchedule()
+	if err := t.update(s); err != nil {
+		return err
+	}
+	if len(t.history) == maxHistory {
+		t.history = append(t.history[:0], t.history[1:]...)
+	}
+	t.history = append(t.history, prev)
+	t.version++
+	t.saveSchedule()
+	return nil
+}
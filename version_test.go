@@ -0,0 +1,37 @@
+package every
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRollbackDoesNotBlock(t *testing.T) {
+	task, _ := NewTask("1h", func() {})
+	if err := within(t, task.Rollback); !errors.Is(err, ErrNoHistory) {
+		t.Errorf("Rollback() with no history = %v, want ErrNoHistory", err)
+	}
+	if err := task.UpdateInterval("2h"); err != nil {
+		t.Fatal(err)
+	}
+	if err := within(t, task.Rollback); err != nil || task.Schedule() != "1h" {
+		t.Errorf("Rollback() before Start = %v, schedule %q", err, task.Schedule())
+	}
+
+	task.UpdateInterval("2h")
+	task.Stop()
+	if err := within(t, task.Rollback); !errors.Is(err, ErrTaskStopped) {
+		t.Errorf("Rollback() after Stop = %v, want ErrTaskStopped", err)
+	}
+
+	sv := NewSupervisor(0, time.Minute)
+	crashing, _ := NewTask("1s", func() { panic("boom") })
+	sv.Add(crashing)
+	crashing.UpdateInterval("1s")
+	crashing.Start()
+	defer crashing.Stop()
+	<-crashing.Done()
+	if err := within(t, crashing.Rollback); !errors.Is(err, ErrTaskStopped) {
+		t.Errorf("Rollback() after the Supervisor gave up = %v, want ErrTaskStopped", err)
+	}
+}
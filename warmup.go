@@ -0,0 +1,48 @@
+package every
+
+import "time"
+
+// defaultWarmupBackoff retries a failed warm-up after 1s, doubling up to
+// 1m.
+var defaultWarmupBackoff = ExponentialBackoff{Initial: time.Second, Max: time.Minute}
+
+// WithWarmup runs fn once when the task starts, before its first fire is
+// scheduled. If fn fails it is retried with exponential backoff from 1s up
+// to 1m until it succeeds or the task is stopped.
+func WithWarmup(fn func() error) Option {
+	return func(t *Task) {
+		t.warmup = fn
+	}
+}
+
+// WithWarmupBackoff replaces the backoff between warm-up attempts, for
+// tasks that wait on a connection with its own reconnect policy.
+func WithWarmupBackoff(b Backoff) Option {
+	return func(t *Task) {
+		t.warmupDelay = b
+	}
+}
+
+// warmUp runs the warm-up func until it succeeds, returning false if the
+// task was stopped first.
+func (t *Task) warmUp() bool {
+	if t.warmup == nil {
+		return true
+	}
+
+	var b Backoff = defaultWarmupBackoff
+	if t.warmupDelay != nil {
+		b = t.warmupDelay
+	}
+	for attempt := 1; ; attempt++ {
+		err := t.warmup()
+		if err == nil {
+			return true
+		}
+		delay := b.NextDelay(attempt)
+		t.Logger().Warn("task warm-up failed", "error", err, "retry", delay)
+		if !t.sleep(delay) {
+			return false
+		}
+	}
+}
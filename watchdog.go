@@ -0,0 +1,54 @@
+package every
+
+import (
+	"context"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// NewWatchdogTask returns a task that sends WATCHDOG=1 to systemd at half
+// of the WatchdogSec= configured for the service. It returns ErrNoWatchdog
+// when the process was not started with the watchdog enabled.
+func NewWatchdogTask() (*Task, error) {
+	socket := os.Getenv("NOTIFY_SOCKET")
+	usec, err := strconv.ParseInt(os.Getenv("WATCHDOG_USEC"), 10, 64)
+	if socket == "" || err != nil || usec <= 0 {
+		return nil, ErrNoWatchdog
+	}
+	if pid := os.Getenv("WATCHDOG_PID"); pid != "" && pid != strconv.Itoa(os.Getpid()) {
+		return nil, ErrNoWatchdog
+	}
+
+	interval := time.Duration(usec) * time.Microsecond / 2
+	return newTask(intervalSchedule(interval), func(context.Context) error {
+		return sdNotify(socket, "WATCHDOG=1")
+	}), nil
+}
+
+func (s *Scheduler) EnableWatchdog() error {
+	t, err := NewWatchdogTask()
+	if err != nil {
+		return err
+	}
+
+	s.Add(t)
+	return nil
+}
+
+func sdNotify(socket, state string) error {
+	if strings.HasPrefix(socket, "@") {
+		socket = "\x00" + socket[1:]
+	}
+
+	conn, err := net.DialUnix("unixgram", nil, &net.UnixAddr{Name: socket, Net: "unixgram"})
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	_, err = conn.Write([]byte(state))
+	return err
+}
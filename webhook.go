@@ -0,0 +1,123 @@
+package every
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const (
+	webhookAttempts = 3
+	webhookTimeout  = 10 * time.Second
+	webhookBackoff  = time.Second
+)
+
+// Webhook is a Notifier that POSTs a JSON payload to URL. With a Secret
+// the body is signed with HMAC-SHA256 and the hex digest sent in the
+// X-Every-Signature header as "sha256=<digest>". Deliveries that fail with
+// a network error or a 429 or 5xx response are retried twice, 1s and 2s
+// apart.
+type Webhook struct {
+	URL    string
+	Secret string
+	// Client defaults to an http.Client with a 10s timeout.
+	Client *http.Client
+}
+
+// WebhookPayload is the JSON body a Webhook sends.
+type WebhookPayload struct {
+	Event    EventType     `json:"event"`
+	Task     string        `json:"task"`
+	Run      uint64        `json:"run,omitempty"`
+	Sequence uint64        `json:"sequence,omitempty"`
+	Missed   int           `json:"missed,omitempty"`
+	Time     time.Time     `json:"time"`
+	Duration time.Duration `json:"duration,omitempty"`
+	Error    string        `json:"error,omitempty"`
+	Reason   string        `json:"reason,omitempty"`
+}
+
+// WithWebhook notifies w whenever a run fails or times out, and when the
+// task's WithCircuitBreaker circuit opens. It is short for
+// WithNotifier(w, EventFailure, EventTimeout, EventCircuitOpen).
+func WithWebhook(w *Webhook) Option {
+	return WithNotifier(w, EventFailure, EventTimeout, EventCircuitOpen)
+}
+
+func (w *Webhook) Notify(ctx context.Context, e Event) error {
+	p := WebhookPayload{Event: e.Type, Task: e.Task, Run: e.Run, Sequence: e.Sequence, Missed: e.Missed, Time: e.Time, Duration: e.Duration}
+	if e.Err != nil {
+		p.Error = e.Err.Error()
+	}
+	if e.Type == EventSkip {
+		p.Reason = e.Reason.String()
+	}
+	return w.send(ctx, p)
+}
+
+func (w *Webhook) send(ctx context.Context, p WebhookPayload) error {
+	body, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+	client := w.Client
+	if client == nil {
+		client = &http.Client{Timeout: webhookTimeout}
+	}
+
+	delay := webhookBackoff
+	for attempt := 1; ; attempt++ {
+		err = w.post(ctx, client, body)
+		if err == nil || attempt == webhookAttempts {
+			return err
+		}
+		if _, ok := err.(permanentError); ok {
+			return err
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+		delay *= 2
+	}
+}
+
+// permanentError is a delivery failure that retrying will not fix.
+type permanentError struct{ error }
+
+func (w *Webhook) post(ctx context.Context, client *http.Client, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.URL, bytes.NewReader(body))
+	if err != nil {
+		return permanentError{err}
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if w.Secret != "" {
+		mac := hmac.New(sha256.New, []byte(w.Secret))
+		mac.Write(body)
+		req.Header.Set("X-Every-Signature", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	switch {
+	case resp.StatusCode < 300:
+		return nil
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		return fmt.Errorf("webhook %s: %s", w.URL, resp.Status)
+	}
+	return permanentError{fmt.Errorf("webhook %s: %s", w.URL, resp.Status)}
+}
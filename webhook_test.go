@@ -0,0 +1,67 @@
+package every
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestWebhookTimeoutAndCircuitOpen(t *testing.T) {
+	got := make(chan string, 4)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mac := hmac.New(sha256.New, []byte("key"))
+		mac.Write(body)
+		if r.Header.Get("X-Every-Signature") != "sha256="+hex.EncodeToString(mac.Sum(nil)) {
+			t.Error("bad signature")
+		}
+		var p struct {
+			Event string `json:"event"`
+		}
+		if err := json.Unmarshal(body, &p); err != nil {
+			t.Error(err)
+		}
+		got <- p.Event
+	}))
+	defer srv.Close()
+
+	task, err := NewErrorTask("1s", func(ctx context.Context) error {
+		<-ctx.Done()
+		return nil
+	}, WithTimeout(time.Millisecond), WithCircuitBreaker(1, time.Hour), WithWebhook(&Webhook{URL: srv.URL, Secret: "key"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	task.timer = timers.get()
+	task.begin()
+	defer func() {
+		timers.put(task.timer)
+		task.closeOutbox()
+		task.cancel()
+	}()
+	now := time.Now()
+	task.due = now
+	task.fire(now)
+
+	var events []string
+	for range 2 {
+		select {
+		case e := <-got:
+			events = append(events, e)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("webhook got %v, want timeout and circuit-open", events)
+		}
+	}
+	slices.Sort(events)
+	if !slices.Equal(events, []string{"circuit-open", "timeout"}) {
+		t.Errorf("webhook got %v, want timeout and circuit-open", events)
+	}
+}